@@ -5,18 +5,28 @@ import (
 	"github.com/iotaledger/goshimmer/plugins/autopeering"
 	"github.com/iotaledger/goshimmer/plugins/bundleprocessor"
 	"github.com/iotaledger/goshimmer/plugins/cli"
+	"github.com/iotaledger/goshimmer/plugins/consensus"
 	"github.com/iotaledger/goshimmer/plugins/dashboard"
+	"github.com/iotaledger/goshimmer/plugins/database"
 	"github.com/iotaledger/goshimmer/plugins/gossip"
 	gossip_on_solidification "github.com/iotaledger/goshimmer/plugins/gossip-on-solidification"
 	"github.com/iotaledger/goshimmer/plugins/gracefulshutdown"
+	"github.com/iotaledger/goshimmer/plugins/ledger"
 	"github.com/iotaledger/goshimmer/plugins/metrics"
+	"github.com/iotaledger/goshimmer/plugins/schema"
+	"github.com/iotaledger/goshimmer/plugins/snapshot"
 	"github.com/iotaledger/goshimmer/plugins/statusscreen"
 	statusscreen_tps "github.com/iotaledger/goshimmer/plugins/statusscreen-tps"
 	"github.com/iotaledger/goshimmer/plugins/tangle"
 	"github.com/iotaledger/goshimmer/plugins/tipselection"
 	"github.com/iotaledger/goshimmer/plugins/ui"
 	"github.com/iotaledger/goshimmer/plugins/webapi"
+	webapi_attach_to_tangle "github.com/iotaledger/goshimmer/plugins/webapi-attach-to-tangle"
+	webapi_backup "github.com/iotaledger/goshimmer/plugins/webapi-backup"
+	webapi_database_stats "github.com/iotaledger/goshimmer/plugins/webapi-database-stats"
 	webapi_gtta "github.com/iotaledger/goshimmer/plugins/webapi-gtta"
+	webapi_inclusion_states "github.com/iotaledger/goshimmer/plugins/webapi-inclusion-states"
+	webapi_neighbors "github.com/iotaledger/goshimmer/plugins/webapi-neighbors"
 	webapi_spammer "github.com/iotaledger/goshimmer/plugins/webapi-spammer"
 	"github.com/iotaledger/goshimmer/plugins/webauth"
 	"github.com/iotaledger/goshimmer/plugins/zeromq"
@@ -26,11 +36,16 @@ import (
 func main() {
 	node.Run(
 		cli.PLUGIN,
+		database.PLUGIN,
+		schema.PLUGIN,
 		autopeering.PLUGIN,
 		gossip.PLUGIN,
 		gossip_on_solidification.PLUGIN,
+		snapshot.PLUGIN,
 		tangle.PLUGIN,
 		bundleprocessor.PLUGIN,
+		ledger.PLUGIN,
+		consensus.PLUGIN,
 		analysis.PLUGIN,
 		gracefulshutdown.PLUGIN,
 		tipselection.PLUGIN,
@@ -42,7 +57,12 @@ func main() {
 		statusscreen_tps.PLUGIN,
 
 		webapi.PLUGIN,
+		webapi_attach_to_tangle.PLUGIN,
+		webapi_backup.PLUGIN,
 		webapi_gtta.PLUGIN,
+		webapi_database_stats.PLUGIN,
+		webapi_inclusion_states.PLUGIN,
+		webapi_neighbors.PLUGIN,
 		webapi_spammer.PLUGIN,
 
 		ui.PLUGIN,
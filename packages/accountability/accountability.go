@@ -25,11 +25,11 @@ func initOwnId() {
 func generateNewIdentity() *identity.Identity {
 	newIdentity := identity.GenerateRandomIdentity()
 
-	if err := settings.Set([]byte("ACCOUNTABILITY_PUBLIC_KEY"), newIdentity.PublicKey); err != nil {
+	if err := settings.Set([]byte(SETTINGS_KEY_PUBLIC_KEY), newIdentity.PublicKey); err != nil {
 		panic(err)
 	}
 
-	if err := settings.Set([]byte("ACCOUNTABILITY_PRIVATE_KEY"), newIdentity.PrivateKey); err != nil {
+	if err := settings.Set([]byte(SETTINGS_KEY_PRIVATE_KEY), newIdentity.PrivateKey); err != nil {
 		panic(err)
 	}
 
@@ -37,7 +37,7 @@ func generateNewIdentity() *identity.Identity {
 }
 
 func getIdentity() *identity.Identity {
-	publicKey, err := settings.Get([]byte("ACCOUNTABILITY_PUBLIC_KEY"))
+	publicKey, err := settings.Get([]byte(SETTINGS_KEY_PUBLIC_KEY))
 	if err != nil {
 		if err == database.ErrKeyNotFound {
 			return generateNewIdentity()
@@ -46,7 +46,7 @@ func getIdentity() *identity.Identity {
 		}
 	}
 
-	privateKey, err := settings.Get([]byte("ACCOUNTABILITY_PRIVATE_KEY"))
+	privateKey, err := settings.Get([]byte(SETTINGS_KEY_PRIVATE_KEY))
 	if err != nil {
 		if err == database.ErrKeyNotFound {
 			return generateNewIdentity()
@@ -57,3 +57,8 @@ func getIdentity() *identity.Identity {
 
 	return identity.NewIdentity(publicKey, privateKey)
 }
+
+const (
+	SETTINGS_KEY_PUBLIC_KEY  = "ACCOUNTABILITY_PUBLIC_KEY"
+	SETTINGS_KEY_PRIVATE_KEY = "ACCOUNTABILITY_PRIVATE_KEY"
+)
@@ -0,0 +1,84 @@
+package backup
+
+import (
+	"io"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/accountability"
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/goshimmer/packages/schema"
+	"github.com/iotaledger/goshimmer/packages/settings"
+)
+
+// Writes a backup of the running node to the given writer. It consists of the header (schema version and identity of
+// the node) followed by a consistent snapshot of the database. Changes that are still cached in memory are not part of
+// the backup, so the caches should be flushed first. The key pair of the node is only part of the backup if
+// includeIdentity is true - otherwise the restored node generates a new identity.
+func Create(w io.Writer, includeIdentity bool) (*Header, errors.IdentifiableError) {
+	schemaVersion, err := schema.GetVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	header := &Header{
+		SchemaVersion:    schemaVersion,
+		Identity:         accountability.OwnId(),
+		CreationTime:     time.Now(),
+		IdentityIncluded: includeIdentity,
+	}
+
+	if _, err := w.Write(header.Marshal()); err != nil {
+		return nil, ErrIOError.Derive(err, "failed to write the header")
+	}
+
+	var filter func(databaseName string, key []byte) bool
+	if !includeIdentity {
+		filter = excludeIdentity
+	}
+
+	if err := database.Backup(w, filter); err != nil {
+		return nil, ErrDatabaseError.Derive(err, "failed to write the database entries")
+	}
+
+	return header, nil
+}
+
+// Loads a backup into the (empty) database. Backups with an older schema version are migrated by the schema plugin when
+// the node starts. Backups that contain the identity of the node that created them are only restored if restoreIdentity
+// is true (two nodes with the same identity can not be connected to the same network).
+func Restore(r io.Reader, restoreIdentity bool) (*Header, errors.IdentifiableError) {
+	header, err := ReadHeader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if header.IdentityIncluded && !restoreIdentity {
+		return nil, ErrIdentityIncluded.Derive(errors.New("the backup contains the identity of node "+header.Identity.StringIdentifier), "failed to restore the backup")
+	}
+
+	if header.SchemaVersion > schema.CURRENT_VERSION {
+		return nil, ErrUnknownVersion.Derive(errors.Errorf("schema version %d is not supported", header.SchemaVersion), "failed to restore the backup")
+	}
+
+	if empty, err := database.IsEmpty(); err != nil {
+		return nil, ErrDatabaseError.Derive(err, "failed to check the database")
+	} else if !empty {
+		return nil, ErrDatabaseNotEmpty.Derive(errors.New("the database contains entries"), "failed to restore the backup")
+	}
+
+	if err := database.Restore(r); err != nil {
+		return nil, ErrDatabaseError.Derive(err, "failed to load the database entries")
+	}
+
+	return header, nil
+}
+
+// filters the key pair of the node from the entries of the backup
+func excludeIdentity(databaseName string, key []byte) bool {
+	if databaseName != settings.DATABASE_NAME {
+		return true
+	}
+
+	return string(key) != accountability.SETTINGS_KEY_PUBLIC_KEY && string(key) != accountability.SETTINGS_KEY_PRIVATE_KEY
+}
@@ -0,0 +1,150 @@
+package backup
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/iotaledger/goshimmer/packages/accountability"
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/goshimmer/packages/schema"
+	"github.com/iotaledger/goshimmer/packages/settings"
+	"github.com/iotaledger/hive.go/parameter"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestMain(m *testing.M) {
+	parameter.FetchConfig(false)
+	parameter.NodeConfig.Set(database.CFG_BACKEND, database.BACKEND_BADGER)
+	os.Exit(m.Run())
+}
+
+func TestBackup(t *testing.T) {
+	sourceDirectory := createTemporaryDirectory(t)
+	defer os.RemoveAll(sourceDirectory)
+
+	// create backups (with and without the identity) of a database with some entries
+	parameter.NodeConfig.Set(database.CFG_DIRECTORY, sourceDirectory)
+	db, err := database.Get("backupTest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Set([]byte("key"), []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+
+	var backupWithoutIdentity bytes.Buffer
+	createdHeader, backupErr := Create(&backupWithoutIdentity, false)
+	if backupErr != nil {
+		t.Fatal(backupErr)
+	}
+	var backupWithIdentity bytes.Buffer
+	if _, backupErr := Create(&backupWithIdentity, true); backupErr != nil {
+		t.Fatal(backupErr)
+	}
+	if err := database.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// restore it into an empty database
+	restoredHeader, restoreErr := restoreIntoTemporaryDirectory(t, backupWithoutIdentity.Bytes(), false, func() {
+		if db, err = database.Get("backupTest"); err != nil {
+			t.Fatal(err)
+		}
+		value, err := db.Get([]byte("key"))
+		assert.Equal(t, err, nil)
+		assert.Equal(t, value, []byte("value"))
+
+		// the key pair of the node is not part of the backup
+		assert.Equal(t, getIdentitySetting(t, accountability.SETTINGS_KEY_PRIVATE_KEY), []byte(nil))
+
+		// a database with entries is not overwritten
+		_, restoreErr := Restore(bytes.NewReader(backupWithoutIdentity.Bytes()), false)
+		assert.Equal(t, ErrDatabaseNotEmpty.Equals(restoreErr), true)
+	})
+	if restoreErr != nil {
+		t.Fatal(restoreErr)
+	}
+	assert.Equal(t, restoredHeader.SchemaVersion, createdHeader.SchemaVersion)
+	assert.Equal(t, restoredHeader.Identity.StringIdentifier, accountability.OwnId().StringIdentifier)
+	assert.Equal(t, restoredHeader.CreationTime.Equal(createdHeader.CreationTime), true)
+	assert.Equal(t, restoredHeader.IdentityIncluded, false)
+
+	// backups with the identity are only restored if explicitly requested
+	_, restoreErr = restoreIntoTemporaryDirectory(t, backupWithIdentity.Bytes(), false, func() {})
+	assert.Equal(t, ErrIdentityIncluded.Equals(restoreErr), true)
+
+	restoredHeader, restoreErr = restoreIntoTemporaryDirectory(t, backupWithIdentity.Bytes(), true, func() {
+		assert.Equal(t, getIdentitySetting(t, accountability.SETTINGS_KEY_PRIVATE_KEY), accountability.OwnId().PrivateKey)
+	})
+	if restoreErr != nil {
+		t.Fatal(restoreErr)
+	}
+	assert.Equal(t, restoredHeader.IdentityIncluded, true)
+}
+
+// restores the backup into a new database and calls the verify function before the database is closed again
+func restoreIntoTemporaryDirectory(t *testing.T, backup []byte, restoreIdentity bool, verify func()) (*Header, errors.IdentifiableError) {
+	targetDirectory := createTemporaryDirectory(t)
+	defer os.RemoveAll(targetDirectory)
+
+	parameter.NodeConfig.Set(database.CFG_DIRECTORY, targetDirectory)
+	defer database.Close()
+
+	header, err := Restore(bytes.NewReader(backup), restoreIdentity)
+	if err == nil {
+		verify()
+	}
+
+	return header, err
+}
+
+func getIdentitySetting(t *testing.T, key string) []byte {
+	settingsDatabase, err := database.Get(settings.DATABASE_NAME)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := settingsDatabase.Get([]byte(key))
+	if err == database.ErrKeyNotFound {
+		return nil
+	} else if err != nil {
+		t.Fatal(err)
+	}
+
+	return value
+}
+
+func TestReadHeader(t *testing.T) {
+	header := &Header{
+		SchemaVersion: schema.CURRENT_VERSION,
+		Identity:      accountability.OwnId(),
+	}
+
+	readHeader, err := ReadHeader(bytes.NewReader(header.Marshal()))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, readHeader.SchemaVersion, uint32(schema.CURRENT_VERSION))
+	assert.Equal(t, readHeader.Identity.PublicKey, header.Identity.PublicKey)
+	assert.Equal(t, readHeader.IdentityIncluded, false)
+
+	// backups of the first format version have no flags and always contain the identity
+	firstVersionHeader := header.Marshal()
+	firstVersionHeader[MARSHALED_FORMAT_VERSION_START] = 1
+	readHeader, err = ReadHeader(bytes.NewReader(firstVersionHeader[:len(firstVersionHeader)-MARSHALED_FLAGS_SIZE]))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, readHeader.IdentityIncluded, true)
+
+	_, err = ReadHeader(bytes.NewReader([]byte("NOT_A_BACKUP_FILE_AT_ALL")))
+	assert.Equal(t, ErrInvalidBackup.Equals(err), true)
+}
+
+func createTemporaryDirectory(t *testing.T) string {
+	directory, err := ioutil.TempDir("", "backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return directory
+}
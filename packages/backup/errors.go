@@ -0,0 +1,14 @@
+package backup
+
+import (
+	"github.com/iotaledger/goshimmer/packages/errors"
+)
+
+var (
+	ErrInvalidBackup    = errors.Wrap(errors.New("backup error"), "the file is not a valid backup")
+	ErrUnknownVersion   = errors.Wrap(errors.New("backup error"), "the backup was created by a newer version of the node")
+	ErrDatabaseNotEmpty = errors.Wrap(errors.New("backup error"), "backups can only be restored into an empty database")
+	ErrIdentityIncluded = errors.Wrap(errors.New("backup error"), "the backup contains the identity of another node")
+	ErrDatabaseError    = errors.Wrap(errors.New("database error"), "failed to access the database")
+	ErrIOError          = errors.Wrap(errors.New("io error"), "failed to read or write the backup")
+)
@@ -0,0 +1,100 @@
+package backup
+
+import (
+	"encoding/binary"
+	"io"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/goshimmer/packages/identity"
+)
+
+// Header is written in front of the database entries of a backup. It identifies the node that created the backup and
+// the schema version of its database. IdentityIncluded is true if the backup contains the private key of the node (a
+// node that restores it takes over the identity of the node that created it).
+type Header struct {
+	SchemaVersion    uint32
+	Identity         *identity.Identity
+	CreationTime     time.Time
+	IdentityIncluded bool
+}
+
+func (header *Header) Marshal() []byte {
+	publicKey := header.Identity.PublicKey
+
+	result := make([]byte, HEADER_FIXED_SIZE+len(publicKey)+MARSHALED_FLAGS_SIZE)
+	copy(result[MARSHALED_MAGIC_START:MARSHALED_MAGIC_END], MAGIC)
+	result[MARSHALED_FORMAT_VERSION_START] = FORMAT_VERSION
+	binary.BigEndian.PutUint32(result[MARSHALED_SCHEMA_VERSION_START:MARSHALED_SCHEMA_VERSION_END], header.SchemaVersion)
+	binary.BigEndian.PutUint64(result[MARSHALED_CREATION_TIME_START:MARSHALED_CREATION_TIME_END], uint64(header.CreationTime.UnixNano()))
+	binary.BigEndian.PutUint16(result[MARSHALED_PUBLIC_KEY_LENGTH_START:MARSHALED_PUBLIC_KEY_LENGTH_END], uint16(len(publicKey)))
+	copy(result[HEADER_FIXED_SIZE:], publicKey)
+	if header.IdentityIncluded {
+		result[HEADER_FIXED_SIZE+len(publicKey)] |= FLAG_IDENTITY_INCLUDED
+	}
+
+	return result
+}
+
+// Reads the header from the beginning of a backup.
+func ReadHeader(r io.Reader) (*Header, errors.IdentifiableError) {
+	fixedPart := make([]byte, HEADER_FIXED_SIZE)
+	if _, err := io.ReadFull(r, fixedPart); err != nil {
+		return nil, ErrInvalidBackup.Derive(err, "failed to read the header")
+	}
+
+	if string(fixedPart[MARSHALED_MAGIC_START:MARSHALED_MAGIC_END]) != MAGIC {
+		return nil, ErrInvalidBackup.Derive(errors.New("invalid magic bytes"), "failed to read the header")
+	}
+	formatVersion := fixedPart[MARSHALED_FORMAT_VERSION_START]
+	if formatVersion == 0 || formatVersion > FORMAT_VERSION {
+		return nil, ErrUnknownVersion.Derive(errors.Errorf("unknown format version %d", formatVersion), "failed to read the header")
+	}
+
+	publicKey := make([]byte, binary.BigEndian.Uint16(fixedPart[MARSHALED_PUBLIC_KEY_LENGTH_START:MARSHALED_PUBLIC_KEY_LENGTH_END]))
+	if _, err := io.ReadFull(r, publicKey); err != nil {
+		return nil, ErrInvalidBackup.Derive(err, "failed to read the public key")
+	}
+
+	// the first version of the format had no flags and always contained the identity
+	identityIncluded := true
+	if formatVersion >= 2 {
+		flags := make([]byte, MARSHALED_FLAGS_SIZE)
+		if _, err := io.ReadFull(r, flags); err != nil {
+			return nil, ErrInvalidBackup.Derive(err, "failed to read the flags")
+		}
+
+		identityIncluded = flags[0]&FLAG_IDENTITY_INCLUDED != 0
+	}
+
+	return &Header{
+		SchemaVersion:    binary.BigEndian.Uint32(fixedPart[MARSHALED_SCHEMA_VERSION_START:MARSHALED_SCHEMA_VERSION_END]),
+		Identity:         identity.NewIdentity(publicKey),
+		CreationTime:     time.Unix(0, int64(binary.BigEndian.Uint64(fixedPart[MARSHALED_CREATION_TIME_START:MARSHALED_CREATION_TIME_END]))),
+		IdentityIncluded: identityIncluded,
+	}, nil
+}
+
+const (
+	MAGIC          = "GOSHIMMER_BACKUP"
+	FORMAT_VERSION = 2
+
+	FLAG_IDENTITY_INCLUDED = byte(1)
+
+	MARSHALED_MAGIC_START             = 0
+	MARSHALED_FORMAT_VERSION_START    = MARSHALED_MAGIC_END
+	MARSHALED_SCHEMA_VERSION_START    = MARSHALED_FORMAT_VERSION_END
+	MARSHALED_CREATION_TIME_START     = MARSHALED_SCHEMA_VERSION_END
+	MARSHALED_PUBLIC_KEY_LENGTH_START = MARSHALED_CREATION_TIME_END
+
+	MARSHALED_MAGIC_END             = MARSHALED_MAGIC_START + len(MAGIC)
+	MARSHALED_FORMAT_VERSION_END    = MARSHALED_FORMAT_VERSION_START + 1
+	MARSHALED_SCHEMA_VERSION_END    = MARSHALED_SCHEMA_VERSION_START + 4
+	MARSHALED_CREATION_TIME_END     = MARSHALED_CREATION_TIME_START + 8
+	MARSHALED_PUBLIC_KEY_LENGTH_END = MARSHALED_PUBLIC_KEY_LENGTH_START + 2
+
+	HEADER_FIXED_SIZE = MARSHALED_PUBLIC_KEY_LENGTH_END
+
+	// the flags follow the public key
+	MARSHALED_FLAGS_SIZE = 1
+)
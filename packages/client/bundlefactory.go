@@ -1,7 +1,11 @@
 package client
 
 import (
+	"context"
+
+	"github.com/iotaledger/goshimmer/packages/errors"
 	"github.com/iotaledger/goshimmer/packages/model/value_transaction"
+	"github.com/iotaledger/goshimmer/packages/pow"
 	"github.com/iotaledger/iota.go/consts"
 	"github.com/iotaledger/iota.go/converter"
 	"github.com/iotaledger/iota.go/signing"
@@ -60,6 +64,32 @@ func (bundleFactory *BundleFactory) GenerateBundle(branchTransactionHash trinary
 	}
 }
 
+// Generates the bundle and does the proof-of-work for all of its transactions. Since every transaction references the
+// hash of its successor, the nonces are searched from the tail to the head.
+func (bundleFactory *BundleFactory) GenerateBundleWithPoW(ctx context.Context, branchTransactionHash trinary.Trytes, trunkTransactionHash trinary.Trytes, minWeightMagnitude int, optionalOptions ...pow.Option) (*Bundle, errors.IdentifiableError) {
+	transactions := bundleFactory.generateTransactions()
+
+	bundleHash := bundleFactory.signTransactions(transactions)
+
+	for i := len(transactions) - 1; i >= 0; i-- {
+		transactions[i].SetBranchTransactionHash(branchTransactionHash)
+		if i == len(transactions)-1 {
+			transactions[i].SetTrunkTransactionHash(trunkTransactionHash)
+		} else {
+			transactions[i].SetTrunkTransactionHash(transactions[i+1].GetHash())
+		}
+
+		if err := pow.DoPoW(ctx, transactions[i], minWeightMagnitude, optionalOptions...); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Bundle{
+		essenceHash:  bundleHash,
+		transactions: transactions,
+	}, nil
+}
+
 func (bundleFactory *BundleFactory) generateTransactions() []*value_transaction.ValueTransaction {
 	transactions := make([]*value_transaction.ValueTransaction, 0)
 
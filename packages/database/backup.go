@@ -0,0 +1,76 @@
+package database
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/dgraph-io/badger"
+	"github.com/iotaledger/hive.go/parameter"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrBackupNotSupported = errors.New("backups are only supported by the badger backend")
+)
+
+// Writes a consistent snapshot of all entries of the database to the given writer. The database stays usable while the
+// backup is running, the writes that happen in the meantime are not part of the backup. If a filter is given, only the
+// entries that it accepts (it receives the name of the database and the key of every entry) are written.
+func Backup(w io.Writer, filter ...func(databaseName string, key []byte) bool) error {
+	if parameter.NodeConfig.GetString(CFG_BACKEND) != BACKEND_BADGER {
+		return ErrBackupNotSupported
+	}
+
+	stream := GetBadgerInstance().NewStream()
+	stream.LogPrefix = "DB.Backup"
+	if len(filter) >= 1 && filter[0] != nil {
+		stream.ChooseKey = func(item *badger.Item) bool {
+			key := item.Key()
+			if separatorIndex := bytes.IndexByte(key, PREFIX_SEPARATOR); separatorIndex != -1 {
+				return filter[0](string(key[:separatorIndex]), key[separatorIndex+1:])
+			}
+
+			return true
+		}
+	}
+
+	_, err := stream.Backup(w, 0)
+
+	return err
+}
+
+// Loads the entries of a backup (that was created by Backup) into the database.
+func Restore(r io.Reader) error {
+	if parameter.NodeConfig.GetString(CFG_BACKEND) != BACKEND_BADGER {
+		return ErrBackupNotSupported
+	}
+
+	return GetBadgerInstance().Load(r, RESTORE_MAX_PENDING_WRITES)
+}
+
+// Returns true if the database does not contain any entries.
+func IsEmpty() (bool, error) {
+	if parameter.NodeConfig.GetString(CFG_BACKEND) != BACKEND_BADGER {
+		return len(memoryInstance.snapshot(nil, true)) == 0, nil
+	}
+
+	empty := true
+	err := GetBadgerInstance().View(func(txn *badger.Txn) error {
+		iteratorOptions := badger.DefaultIteratorOptions
+		iteratorOptions.PrefetchValues = false
+
+		iterator := txn.NewIterator(iteratorOptions)
+		defer iterator.Close()
+
+		iterator.Rewind()
+		empty = !iterator.Valid()
+
+		return nil
+	})
+
+	return empty, err
+}
+
+const (
+	RESTORE_MAX_PENDING_WRITES = 256
+)
@@ -13,6 +13,9 @@ import (
 var instance *badger.DB
 var once sync.Once
 
+// protects the instance against being closed while it is used by the maintenance functions
+var instanceMutex sync.RWMutex
+
 // Returns whether the given file or directory exists.
 func exists(path string) (bool, error) {
 	_, err := os.Stat(path)
@@ -63,7 +66,33 @@ func GetBadgerInstance() *badger.DB {
 			// errors should cause a panic to avoid singleton deadlocks
 			panic(err)
 		}
+		instanceMutex.Lock()
 		instance = db
+		instanceMutex.Unlock()
 	})
 	return instance
 }
+
+// Closes the database instance and drops the content of the in-memory backend. A subsequent call to
+// GetBadgerInstance opens the database again.
+func Close() error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	instanceMutex.Lock()
+	defer instanceMutex.Unlock()
+
+	dbMap = make(map[string]Database)
+	memoryInstance.clear()
+
+	if instance == nil {
+		return nil
+	}
+
+	err := instance.Close()
+
+	instance = nil
+	once = sync.Once{}
+
+	return err
+}
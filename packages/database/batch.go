@@ -0,0 +1,149 @@
+package database
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrUnsupportedDatabase = errors.New("the database can not be used in this batch")
+)
+
+// Creates a new batch for the databases of the configured backend. The writes of a batch are committed in a single
+// transaction, so either all or none of them are persisted. Only batches that exceed the maximum transaction size of
+// badger are split into several transactions.
+func NewBatch() Batch {
+	return &batch{
+		operations: make([]*batchOperation, 0),
+	}
+}
+
+type batch struct {
+	operations      []*batchOperation
+	operationsMutex sync.Mutex
+	badgerInstance  *badger.DB
+	memoryInstance  *memoryStorage
+}
+
+type batchOperation struct {
+	key    []byte
+	value  []byte
+	ttl    time.Duration
+	delete bool
+}
+
+func (this *batch) Set(db Database, key []byte, value []byte) error {
+	return this.addOperation(db, key, &batchOperation{value: value})
+}
+
+func (this *batch) SetWithTTL(db Database, key []byte, value []byte, ttl time.Duration) error {
+	return this.addOperation(db, key, &batchOperation{value: value, ttl: ttl})
+}
+
+func (this *batch) Delete(db Database, key []byte) error {
+	return this.addOperation(db, key, &batchOperation{delete: true})
+}
+
+func (this *batch) Size() int {
+	this.operationsMutex.Lock()
+	defer this.operationsMutex.Unlock()
+
+	return len(this.operations)
+}
+
+// Writes all collected operations to the database. The batch is empty afterwards and can be reused.
+func (this *batch) Commit() error {
+	this.operationsMutex.Lock()
+	defer this.operationsMutex.Unlock()
+
+	operations := this.operations
+	this.operations = make([]*batchOperation, 0)
+
+	if len(operations) == 0 {
+		return nil
+	}
+
+	if this.memoryInstance != nil {
+		this.memoryInstance.apply(operations)
+
+		return nil
+	}
+
+	countWrites(len(operations))
+
+	return commitToBadger(this.badgerInstance, operations)
+}
+
+// records the operation with the prefixed key (all databases of a batch have to use the same storage)
+func (this *batch) addOperation(db Database, key []byte, operation *batchOperation) error {
+	this.operationsMutex.Lock()
+	defer this.operationsMutex.Unlock()
+
+	switch typedDb := db.(type) {
+	case *prefixDb:
+		if this.memoryInstance != nil || (this.badgerInstance != nil && this.badgerInstance != typedDb.db) {
+			return ErrUnsupportedDatabase
+		}
+		this.badgerInstance = typedDb.db
+
+		operation.key = typedDb.prefixedKey(key)
+	case *memoryDb:
+		if this.badgerInstance != nil || (this.memoryInstance != nil && this.memoryInstance != typedDb.storage) {
+			return ErrUnsupportedDatabase
+		}
+		this.memoryInstance = typedDb.storage
+
+		operation.key = typedDb.prefixedKey(key)
+	default:
+		return ErrUnsupportedDatabase
+	}
+
+	if operation.value != nil {
+		operation.value = append([]byte{}, operation.value...)
+	}
+
+	this.operations = append(this.operations, operation)
+
+	return nil
+}
+
+func commitToBadger(db *badger.DB, operations []*batchOperation) error {
+	txn := db.NewTransaction(true)
+	defer func() {
+		txn.Discard()
+	}()
+
+	for _, operation := range operations {
+		err := applyToBadgerTxn(txn, operation)
+		if err == badger.ErrTxnTooBig {
+			if err = txn.Commit(); err != nil {
+				return err
+			}
+
+			txn = db.NewTransaction(true)
+			err = applyToBadgerTxn(txn, operation)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return txn.Commit()
+}
+
+func applyToBadgerTxn(txn *badger.Txn, operation *batchOperation) error {
+	if operation.delete {
+		return txn.Delete(operation.key)
+	}
+
+	entry := badger.NewEntry(operation.key, operation.value)
+	if operation.ttl != 0 {
+		entry = entry.WithTTL(operation.ttl)
+	}
+
+	return txn.SetEntry(entry)
+}
@@ -0,0 +1,52 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/parameter"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestBatch(t *testing.T) {
+	parameter.NodeConfig.Set(CFG_BACKEND, BACKEND_MEMORY)
+	defer Close()
+
+	firstDb, err := Get("batchFirst")
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondDb, err := Get("batchSecond")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := firstDb.Set([]byte("deleted"), []byte("1")); err != nil {
+		t.Fatal(err)
+	}
+
+	batch := NewBatch()
+	if err := batch.Set(firstDb, []byte("key"), []byte("2")); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Set(secondDb, []byte("key"), []byte("3")); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Delete(firstDb, []byte("deleted")); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, batch.Size(), 3)
+
+	// nothing is written before the commit
+	contains, _ := secondDb.Contains([]byte("key"))
+	assert.Equal(t, contains, false)
+
+	if err := batch.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, batch.Size(), 0)
+
+	assert.Equal(t, collectKeys(t, firstDb.ForEach), []string{"key"})
+	value, err := secondDb.Get([]byte("key"))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, value, []byte("3"))
+}
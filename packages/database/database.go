@@ -1,16 +1,19 @@
 package database
 
 import (
+	"bytes"
 	"sync"
 	"time"
 
 	"github.com/dgraph-io/badger"
+	"github.com/iotaledger/hive.go/parameter"
+	"github.com/pkg/errors"
 )
 
 var (
 	ErrKeyNotFound = badger.ErrKeyNotFound
 
-	dbMap = make(map[string]*prefixDb)
+	dbMap = make(map[string]Database)
 	mu    sync.Mutex
 )
 
@@ -20,8 +23,10 @@ type prefixDb struct {
 	prefix []byte
 }
 
+const PREFIX_SEPARATOR = '_'
+
 func getPrefix(name string) []byte {
-	return []byte(name + "_")
+	return append([]byte(name), PREFIX_SEPARATOR)
 }
 
 func Get(name string) (Database, error) {
@@ -32,11 +37,18 @@ func Get(name string) (Database, error) {
 		return db, nil
 	}
 
-	badger := GetBadgerInstance()
-	db := &prefixDb{
-		db:     badger,
-		name:   name,
-		prefix: getPrefix(name),
+	var db Database
+	switch backend := parameter.NodeConfig.GetString(CFG_BACKEND); backend {
+	case BACKEND_BADGER:
+		db = &prefixDb{
+			db:     GetBadgerInstance(),
+			name:   name,
+			prefix: getPrefix(name),
+		}
+	case BACKEND_MEMORY:
+		db = newMemoryDb(name)
+	default:
+		return nil, errors.Errorf("unknown database backend '%s'", backend)
 	}
 
 	dbMap[name] = db
@@ -48,6 +60,7 @@ func (this *prefixDb) setEntry(e *badger.Entry) error {
 	err := this.db.Update(func(txn *badger.Txn) error {
 		return txn.SetEntry(e)
 	})
+	countWrites(1)
 	return err
 }
 
@@ -97,6 +110,7 @@ func (this *prefixDb) Delete(key []byte) error {
 	err := this.db.Update(func(txn *badger.Txn) error {
 		return txn.Delete(append(this.prefix, key...))
 	})
+	countWrites(1)
 	return err
 }
 
@@ -132,3 +146,53 @@ func (this *prefixDb) ForEachWithPrefix(prefix []byte, consumer func([]byte, []b
 func (this *prefixDb) ForEach(consumer func([]byte, []byte)) error {
 	return this.forEach(this.prefix, consumer)
 }
+
+// Iterates over the entries that match the options in ascending (or descending) key order until the consumer returns
+// false. The key and value are only valid during the call of the consumer. If the iteration was stopped early (by the
+// consumer or the limit), the returned cursor can be used to continue it.
+func (this *prefixDb) Iterate(consumer func(key []byte, value []byte) bool, options ...IteratorOption) (cursor []byte, err error) {
+	iteration := newIteration(consumer, options...)
+
+	err = this.db.View(func(txn *badger.Txn) error {
+		iteratorOptions := badger.DefaultIteratorOptions
+		iteratorOptions.Prefix = this.prefixedKey(iteration.options.Prefix)
+		iteratorOptions.PrefetchValues = !iteration.options.KeysOnly
+		iteratorOptions.Reverse = iteration.options.Reverse
+
+		it := txn.NewIterator(iteratorOptions)
+		defer it.Close()
+
+		it.Seek(iteration.seekKey(this.prefix))
+
+		// a reverse seek returns the seek key itself if it exists, which is the successor of the prefix if no other
+		// bound was given
+		if iteration.options.Reverse && it.Item() != nil && !bytes.HasPrefix(it.Item().Key(), iteratorOptions.Prefix) {
+			it.Next()
+		}
+
+		for ; it.ValidForPrefix(iteratorOptions.Prefix); it.Next() {
+			item := it.Item()
+
+			if proceed, err := iteration.process(item.Key()[len(this.prefix):], func() ([]byte, error) {
+				return item.ValueCopy(nil)
+			}); err != nil {
+				return err
+			} else if !proceed {
+				break
+			}
+		}
+
+		return nil
+	})
+
+	return iteration.cursor, err
+}
+
+// returns the key with the prefix of the database (in a new slice, so the prefix itself is never modified)
+func (this *prefixDb) prefixedKey(key []byte) []byte {
+	result := make([]byte, len(this.prefix)+len(key))
+	copy(result, this.prefix)
+	copy(result[len(this.prefix):], key)
+
+	return result
+}
@@ -9,5 +9,15 @@ type Database interface {
 	Get(key []byte) ([]byte, error)
 	ForEach(consumer func(key []byte, value []byte)) error
 	ForEachWithPrefix(prefix []byte, consumer func(key []byte, value []byte)) error
+	Iterate(consumer func(key []byte, value []byte) bool, options ...IteratorOption) (cursor []byte, err error)
 	Delete(key []byte) error
 }
+
+// Batch collects writes to one or more databases and applies them together when it is committed.
+type Batch interface {
+	Set(db Database, key []byte, value []byte) error
+	SetWithTTL(db Database, key []byte, value []byte, ttl time.Duration) error
+	Delete(db Database, key []byte) error
+	Commit() error
+	Size() int
+}
@@ -0,0 +1,195 @@
+package database
+
+import (
+	"bytes"
+)
+
+// region iterator options /////////////////////////////////////////////////////////////////////////////////////////////
+
+var DEFAULT_ITERATOR_OPTIONS = &IteratorOptions{
+	KeysOnly: false,
+	Reverse:  false,
+}
+
+// Only iterates over the keys, the consumer receives nil instead of the values (which are not copied at all).
+func KeysOnly() IteratorOption {
+	return func(args *IteratorOptions) {
+		args.KeysOnly = true
+	}
+}
+
+// Only iterates over the keys with the given prefix.
+func WithPrefix(prefix []byte) IteratorOption {
+	return func(args *IteratorOptions) {
+		args.Prefix = prefix
+	}
+}
+
+// Only iterates over the keys in the range [start, end). A nil value leaves the corresponding side of the range open.
+func Range(start []byte, end []byte) IteratorOption {
+	return func(args *IteratorOptions) {
+		args.Start = start
+		args.End = end
+	}
+}
+
+// Iterates in descending instead of ascending key order.
+func Reverse() IteratorOption {
+	return func(args *IteratorOptions) {
+		args.Reverse = true
+	}
+}
+
+// Stops the iteration after the given amount of entries (0 = unlimited). If more entries are available, Iterate returns
+// a cursor that can be passed to After to retrieve the next page.
+func Limit(limit int) IteratorOption {
+	return func(args *IteratorOptions) {
+		args.Limit = limit
+	}
+}
+
+// Continues an iteration after the given cursor (the key that was processed last).
+func After(cursor []byte) IteratorOption {
+	return func(args *IteratorOptions) {
+		args.After = cursor
+	}
+}
+
+type IteratorOptions struct {
+	KeysOnly bool
+	Prefix   []byte
+	Start    []byte
+	End      []byte
+	Reverse  bool
+	Limit    int
+	After    []byte
+}
+
+func (options IteratorOptions) Override(optionalOptions ...IteratorOption) *IteratorOptions {
+	result := &options
+	for _, option := range optionalOptions {
+		option(result)
+	}
+
+	return result
+}
+
+type IteratorOption func(*IteratorOptions)
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region iteration ////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// iteration contains the logic that is shared by the backends: the backends provide the entries with the prefix of the
+// options in the requested order and the iteration filters them and keeps track of the limit and the cursor.
+type iteration struct {
+	options  *IteratorOptions
+	consumer func(key []byte, value []byte) bool
+	count    int
+	lastKey  []byte
+	cursor   []byte
+}
+
+func newIteration(consumer func(key []byte, value []byte) bool, optionalOptions ...IteratorOption) *iteration {
+	return &iteration{
+		options:  DEFAULT_ITERATOR_OPTIONS.Override(optionalOptions...),
+		consumer: consumer,
+	}
+}
+
+// returns the key (with the given prefix of the database) where a backend should start - the backend has to skip the key
+// if it is not part of the iteration
+func (iteration *iteration) seekKey(databasePrefix []byte) []byte {
+	options := iteration.options
+
+	prefixedKey := func(key []byte) []byte {
+		return append(append([]byte{}, databasePrefix...), key...)
+	}
+
+	if !options.Reverse {
+		result := prefixedKey(options.Prefix)
+		for _, candidate := range [][]byte{options.Start, options.After} {
+			if candidate != nil && bytes.Compare(prefixedKey(candidate), result) > 0 {
+				result = prefixedKey(candidate)
+			}
+		}
+
+		return result
+	}
+
+	// the successor of the prefix is the first key that follows all keys with the prefix
+	result := prefixSuccessor(prefixedKey(options.Prefix))
+	for _, candidate := range [][]byte{options.End, options.After} {
+		if candidate != nil && (result == nil || bytes.Compare(prefixedKey(candidate), result) < 0) {
+			result = prefixedKey(candidate)
+		}
+	}
+
+	return result
+}
+
+// passes the entry to the consumer if it is part of the iteration and returns false if the iteration has to stop
+func (iteration *iteration) process(key []byte, value func() ([]byte, error)) (bool, error) {
+	options := iteration.options
+
+	if !options.Reverse {
+		if options.End != nil && bytes.Compare(key, options.End) >= 0 {
+			return false, nil
+		}
+		if (options.Start != nil && bytes.Compare(key, options.Start) < 0) || (options.After != nil && bytes.Compare(key, options.After) <= 0) {
+			return true, nil
+		}
+	} else {
+		if options.Start != nil && bytes.Compare(key, options.Start) < 0 {
+			return false, nil
+		}
+		if (options.End != nil && bytes.Compare(key, options.End) >= 0) || (options.After != nil && bytes.Compare(key, options.After) >= 0) {
+			return true, nil
+		}
+	}
+
+	// there are more entries than requested
+	if options.Limit != 0 && iteration.count >= options.Limit {
+		iteration.cursor = iteration.lastKey
+
+		return false, nil
+	}
+
+	var entryValue []byte
+	if !options.KeysOnly {
+		var err error
+		if entryValue, err = value(); err != nil {
+			return false, err
+		}
+	}
+
+	iteration.count++
+	if options.Limit != 0 {
+		iteration.lastKey = append(iteration.lastKey[:0], key...)
+	}
+
+	if !iteration.consumer(key, entryValue) {
+		iteration.cursor = append([]byte{}, key...)
+
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// returns the smallest key that is bigger than all keys with the given prefix (the prefix with its last byte incremented)
+// or nil if there is no such key
+func prefixSuccessor(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xFF {
+			result := append([]byte{}, prefix[:i+1]...)
+			result[i]++
+
+			return result
+		}
+	}
+
+	return nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,113 @@
+package database
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/iotaledger/hive.go/parameter"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestIterate(t *testing.T) {
+	parameter.NodeConfig.Set(CFG_BACKEND, BACKEND_MEMORY)
+	defer Close()
+
+	testIterate(t)
+}
+
+func TestIterateBadger(t *testing.T) {
+	directory, err := ioutil.TempDir("", "iterate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	parameter.NodeConfig.Set(CFG_BACKEND, BACKEND_BADGER)
+	parameter.NodeConfig.Set(CFG_DIRECTORY, directory)
+	defer parameter.NodeConfig.Set(CFG_BACKEND, BACKEND_MEMORY)
+	defer Close()
+
+	testIterate(t)
+}
+
+// runs the same iterations against the configured backend, so both backends are guaranteed to behave the same
+func testIterate(t *testing.T) {
+	db, err := Get("iterate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"a1", "a2", "a3", "a\xffz", "b", "b1", "b2", "c1"} {
+		if err := db.Set([]byte(key), []byte("value of "+key)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the entries of other databases are never part of the iteration
+	otherDb, err := Get("iterate\xff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := otherDb.Set([]byte("a1"), []byte("value of another database")); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, _ := iterateKeys(t, db)
+	assert.Equal(t, keys, []string{"a1", "a2", "a3", "a\xffz", "b", "b1", "b2", "c1"})
+
+	keys, _ = iterateKeys(t, db, WithPrefix([]byte("b")))
+	assert.Equal(t, keys, []string{"b", "b1", "b2"})
+
+	keys, _ = iterateKeys(t, db, Range([]byte("a2"), []byte("b2")))
+	assert.Equal(t, keys, []string{"a2", "a3", "a\xffz", "b", "b1"})
+
+	keys, _ = iterateKeys(t, db, Reverse())
+	assert.Equal(t, keys, []string{"c1", "b2", "b1", "b", "a\xffz", "a3", "a2", "a1"})
+
+	// the successor of the prefix ("b") exists, so it has to be skipped
+	keys, _ = iterateKeys(t, db, Reverse(), WithPrefix([]byte("a")), Range([]byte("a2"), nil))
+	assert.Equal(t, keys, []string{"a\xffz", "a3", "a2"})
+
+	keys, _ = iterateKeys(t, db, Reverse(), WithPrefix([]byte("c")))
+	assert.Equal(t, keys, []string{"c1"})
+
+	// paging with a cursor
+	keys, cursor := iterateKeys(t, db, Limit(4))
+	assert.Equal(t, keys, []string{"a1", "a2", "a3", "a\xffz"})
+	assert.Equal(t, cursor, []byte("a\xffz"))
+
+	keys, cursor = iterateKeys(t, db, Limit(4), After(cursor))
+	assert.Equal(t, keys, []string{"b", "b1", "b2", "c1"})
+	assert.Equal(t, cursor, []byte(nil))
+
+	keys, cursor = iterateKeys(t, db, Limit(2), Reverse(), After([]byte("b1")))
+	assert.Equal(t, keys, []string{"b", "a\xffz"})
+	assert.Equal(t, cursor, []byte("a\xffz"))
+
+	// early stop and keys only
+	count := 0
+	cursor, err = db.Iterate(func(key []byte, value []byte) bool {
+		assert.Equal(t, value, []byte(nil))
+		count++
+
+		return count < 2
+	}, KeysOnly())
+	assert.Equal(t, err, nil)
+	assert.Equal(t, count, 2)
+	assert.Equal(t, cursor, []byte("a2"))
+}
+
+func iterateKeys(t *testing.T, db Database, options ...IteratorOption) ([]string, []byte) {
+	result := make([]string, 0)
+	cursor, err := db.Iterate(func(key []byte, value []byte) bool {
+		assert.Equal(t, string(value), "value of "+string(key))
+		result = append(result, string(key))
+
+		return true
+	}, options...)
+	if err != nil {
+		t.Error(err)
+	}
+
+	return result, cursor
+}
@@ -0,0 +1,160 @@
+package database
+
+import (
+	"bytes"
+	"sync/atomic"
+
+	"github.com/dgraph-io/badger"
+	"github.com/iotaledger/hive.go/parameter"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrDatabaseClosed = errors.New("the database is closed")
+)
+
+// Stats contains the size information of the database. The sizes are only known for the badger backend and the key counts
+// are only set if they were counted (see CountKeys).
+type Stats struct {
+	LSMSize      int64
+	ValueLogSize int64
+	KeyCounts    map[string]uint64
+}
+
+// Rewrites the value log files of badger that contain more than the given ratio of stale data (i.e. deleted, overwritten
+// or expired entries) until there is nothing left to be reclaimed. It returns the amount of rewritten files. The memory
+// backend only drops its expired entries.
+func RunGarbageCollection(discardRatio float64) (rewrittenFiles int, err error) {
+	if parameter.NodeConfig.GetString(CFG_BACKEND) != BACKEND_BADGER {
+		memoryInstance.removeAllExpired()
+
+		return
+	}
+
+	instanceMutex.RLock()
+	defer instanceMutex.RUnlock()
+
+	// there is nothing to collect if the database was not opened (or is closed already)
+	db := instance
+	if db == nil {
+		return
+	}
+
+	for {
+		if err = db.RunValueLogGC(discardRatio); err != nil {
+			if err == badger.ErrNoRewrite {
+				err = nil
+			}
+
+			return
+		}
+
+		rewrittenFiles++
+	}
+}
+
+// Returns the size of the database (the key counts are not part of the result because counting them requires a full
+// scan of the database - see CountKeys).
+func GetStats() (*Stats, error) {
+	stats := &Stats{}
+
+	if parameter.NodeConfig.GetString(CFG_BACKEND) != BACKEND_BADGER {
+		return stats, nil
+	}
+
+	instanceMutex.RLock()
+	defer instanceMutex.RUnlock()
+
+	if db := instance; db != nil {
+		stats.LSMSize, stats.ValueLogSize = db.Size()
+	}
+
+	return stats, nil
+}
+
+// Returns the amount of keys that are stored in each of the named databases. The keys of badger are counted in chunks
+// that are read in separate transactions, so the database can be closed while the keys are counted (which aborts the
+// counting with ErrDatabaseClosed).
+func CountKeys() (map[string]uint64, error) {
+	keyCounts := make(map[string]uint64)
+
+	countKey := func(key []byte) {
+		if separatorIndex := bytes.IndexByte(key, PREFIX_SEPARATOR); separatorIndex != -1 {
+			keyCounts[string(key[:separatorIndex])]++
+		}
+	}
+
+	if parameter.NodeConfig.GetString(CFG_BACKEND) != BACKEND_BADGER {
+		for _, entry := range memoryInstance.snapshot(nil, true) {
+			countKey(entry.key)
+		}
+
+		return keyCounts, nil
+	}
+
+	var lastKey []byte
+	for {
+		countedKeys, err := countBadgerKeys(lastKey, func(key []byte) {
+			countKey(key)
+
+			lastKey = append(lastKey[:0], key...)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if countedKeys < KEY_COUNT_CHUNK_SIZE {
+			return keyCounts, nil
+		}
+	}
+}
+
+// passes the keys that follow lastKey to the consumer (at most KEY_COUNT_CHUNK_SIZE) and returns how many keys it found
+func countBadgerKeys(lastKey []byte, consumer func(key []byte)) (countedKeys int, err error) {
+	instanceMutex.RLock()
+	defer instanceMutex.RUnlock()
+
+	db := instance
+	if db == nil {
+		return 0, ErrDatabaseClosed
+	}
+
+	err = db.View(func(txn *badger.Txn) error {
+		iteratorOptions := badger.DefaultIteratorOptions
+		iteratorOptions.PrefetchValues = false
+
+		iterator := txn.NewIterator(iteratorOptions)
+		defer iterator.Close()
+
+		iterator.Seek(lastKey)
+		if lastKey != nil && iterator.Valid() && bytes.Equal(iterator.Item().Key(), lastKey) {
+			iterator.Next()
+		}
+
+		for ; iterator.Valid() && countedKeys < KEY_COUNT_CHUNK_SIZE; iterator.Next() {
+			consumer(iterator.Item().Key())
+
+			countedKeys++
+		}
+
+		return nil
+	})
+
+	return
+}
+
+// Returns the amount of write operations that were applied to the badger database since the node started (it can be
+// used to detect idle periods).
+func GetWriteCount() uint64 {
+	return atomic.LoadUint64(&writeCount)
+}
+
+func countWrites(operations int) {
+	atomic.AddUint64(&writeCount, uint64(operations))
+}
+
+var writeCount uint64
+
+const (
+	KEY_COUNT_CHUNK_SIZE = 1000
+)
@@ -0,0 +1,100 @@
+package database
+
+import (
+	"io/ioutil"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/parameter"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestMaintenance(t *testing.T) {
+	parameter.NodeConfig.Set(CFG_BACKEND, BACKEND_MEMORY)
+	defer Close()
+
+	firstDb, err := Get("maintenanceFirst")
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondDb, err := Get("maintenanceSecond")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := firstDb.Set([]byte("key1"), []byte("1")); err != nil {
+		t.Fatal(err)
+	}
+	if err := firstDb.Set([]byte("key2"), []byte("2")); err != nil {
+		t.Fatal(err)
+	}
+	if err := secondDb.SetWithTTL([]byte("key"), []byte("3"), 50*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	keyCounts, err := CountKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, keyCounts, map[string]uint64{"maintenanceFirst": 2, "maintenanceSecond": 1})
+
+	// the garbage collection removes the expired entries
+	time.Sleep(100 * time.Millisecond)
+	if _, err := RunGarbageCollection(0.5); err != nil {
+		t.Fatal(err)
+	}
+	memoryInstance.entriesMutex.RLock()
+	assert.Equal(t, len(memoryInstance.entries), 2)
+	memoryInstance.entriesMutex.RUnlock()
+}
+
+func TestCountKeysBadger(t *testing.T) {
+	directory, err := ioutil.TempDir("", "countKeys")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	parameter.NodeConfig.Set(CFG_BACKEND, BACKEND_BADGER)
+	parameter.NodeConfig.Set(CFG_DIRECTORY, directory)
+	defer parameter.NodeConfig.Set(CFG_BACKEND, BACKEND_MEMORY)
+	defer Close()
+
+	firstDb, err := Get("countKeysFirst")
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondDb, err := Get("countKeysSecond")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the keys are counted in several chunks
+	batch := NewBatch()
+	for i := 0; i < 2*KEY_COUNT_CHUNK_SIZE+10; i++ {
+		if err := batch.Set(firstDb, []byte(strconv.Itoa(i)), []byte{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := batch.Set(secondDb, []byte("key"), []byte{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	keyCounts, err := CountKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, keyCounts, map[string]uint64{"countKeysFirst": 2*KEY_COUNT_CHUNK_SIZE + 10, "countKeysSecond": 1})
+
+	// the counting is aborted if the database is closed
+	if err := Close(); err != nil {
+		t.Fatal(err)
+	}
+	_, err = CountKeys()
+	assert.Equal(t, err, ErrDatabaseClosed)
+}
@@ -0,0 +1,256 @@
+package database
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+	"time"
+)
+
+// memoryDb is a Database that keeps its entries in memory only. All instances share the same storage (like the prefix
+// databases share the badger instance), so the content is lost when the database is closed.
+type memoryDb struct {
+	storage *memoryStorage
+	name    string
+	prefix  []byte
+}
+
+func newMemoryDb(name string) *memoryDb {
+	return &memoryDb{
+		storage: memoryInstance,
+		name:    name,
+		prefix:  getPrefix(name),
+	}
+}
+
+func (this *memoryDb) Set(key []byte, value []byte) error {
+	this.storage.set(this.prefixedKey(key), value, time.Time{})
+
+	return nil
+}
+
+func (this *memoryDb) SetWithTTL(key []byte, value []byte, ttl time.Duration) error {
+	this.storage.set(this.prefixedKey(key), value, time.Now().Add(ttl))
+
+	return nil
+}
+
+func (this *memoryDb) Contains(key []byte) (bool, error) {
+	_, exists := this.storage.get(this.prefixedKey(key))
+
+	return exists, nil
+}
+
+func (this *memoryDb) Get(key []byte) ([]byte, error) {
+	if value, exists := this.storage.get(this.prefixedKey(key)); exists {
+		return value, nil
+	}
+
+	return nil, ErrKeyNotFound
+}
+
+func (this *memoryDb) Delete(key []byte) error {
+	this.storage.delete(this.prefixedKey(key))
+
+	return nil
+}
+
+func (this *memoryDb) ForEachWithPrefix(prefix []byte, consumer func([]byte, []byte)) error {
+	return this.forEach(this.prefixedKey(prefix), consumer)
+}
+
+func (this *memoryDb) ForEach(consumer func([]byte, []byte)) error {
+	return this.forEach(this.prefix, consumer)
+}
+
+// Iterates over the entries that match the options (see prefixDb.Iterate).
+func (this *memoryDb) Iterate(consumer func(key []byte, value []byte) bool, options ...IteratorOption) ([]byte, error) {
+	iteration := newIteration(consumer, options...)
+
+	entries := this.storage.snapshot(this.prefixedKey(iteration.options.Prefix), iteration.options.KeysOnly)
+	for i := range entries {
+		entry := entries[i]
+		if iteration.options.Reverse {
+			entry = entries[len(entries)-1-i]
+		}
+
+		if proceed, err := iteration.process(entry.key[len(this.prefix):], func() ([]byte, error) {
+			return entry.value, nil
+		}); err != nil {
+			return nil, err
+		} else if !proceed {
+			break
+		}
+	}
+
+	return iteration.cursor, nil
+}
+
+func (this *memoryDb) forEach(prefix []byte, consumer func([]byte, []byte)) error {
+	for _, entry := range this.storage.snapshot(prefix, false) {
+		consumer(entry.key[len(this.prefix):], entry.value)
+	}
+
+	return nil
+}
+
+// returns the prefixed key (in a new slice, so the prefix itself is never modified)
+func (this *memoryDb) prefixedKey(key []byte) []byte {
+	result := make([]byte, len(this.prefix)+len(key))
+	copy(result, this.prefix)
+	copy(result[len(this.prefix):], key)
+
+	return result
+}
+
+// region memory storage ///////////////////////////////////////////////////////////////////////////////////////////////
+
+var memoryInstance = newMemoryStorage()
+
+type memoryStorage struct {
+	entries      map[string]*memoryEntry
+	entriesMutex sync.RWMutex
+}
+
+type memoryEntry struct {
+	key        []byte
+	value      []byte
+	expiryTime time.Time
+}
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{
+		entries: make(map[string]*memoryEntry),
+	}
+}
+
+func (storage *memoryStorage) set(key []byte, value []byte, expiryTime time.Time) {
+	storage.entriesMutex.Lock()
+	storage.entries[string(key)] = &memoryEntry{
+		key:        key,
+		value:      append([]byte{}, value...),
+		expiryTime: expiryTime,
+	}
+	storage.entriesMutex.Unlock()
+}
+
+func (storage *memoryStorage) get(key []byte) ([]byte, bool) {
+	storage.entriesMutex.RLock()
+	entry, exists := storage.entries[string(key)]
+	storage.entriesMutex.RUnlock()
+
+	if !exists {
+		return nil, false
+	}
+
+	if entry.isExpired(time.Now()) {
+		storage.removeExpired(entry)
+
+		return nil, false
+	}
+
+	return append([]byte{}, entry.value...), true
+}
+
+func (storage *memoryStorage) delete(key []byte) {
+	storage.entriesMutex.Lock()
+	delete(storage.entries, string(key))
+	storage.entriesMutex.Unlock()
+}
+
+// returns copies of all entries with the given prefix in ascending key order (the same order the badger iterator uses)
+// so the consumers can modify the database while iterating
+func (storage *memoryStorage) snapshot(prefix []byte, keysOnly bool) []*memoryEntry {
+	now := time.Now()
+
+	storage.entriesMutex.RLock()
+	result := make([]*memoryEntry, 0)
+	expiredEntries := make([]*memoryEntry, 0)
+	for _, entry := range storage.entries {
+		if !bytes.HasPrefix(entry.key, prefix) {
+			continue
+		}
+
+		if entry.isExpired(now) {
+			expiredEntries = append(expiredEntries, entry)
+
+			continue
+		}
+
+		snapshotEntry := &memoryEntry{
+			key: entry.key,
+		}
+		if !keysOnly {
+			snapshotEntry.value = append([]byte{}, entry.value...)
+		}
+		result = append(result, snapshotEntry)
+	}
+	storage.entriesMutex.RUnlock()
+
+	for _, entry := range expiredEntries {
+		storage.removeExpired(entry)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return bytes.Compare(result[i].key, result[j].key) < 0
+	})
+
+	return result
+}
+
+// removes the entry if it was not overwritten in the meantime
+func (storage *memoryStorage) removeExpired(entry *memoryEntry) {
+	storage.entriesMutex.Lock()
+	if storage.entries[string(entry.key)] == entry {
+		delete(storage.entries, string(entry.key))
+	}
+	storage.entriesMutex.Unlock()
+}
+
+func (storage *memoryStorage) removeAllExpired() {
+	now := time.Now()
+
+	storage.entriesMutex.Lock()
+	for key, entry := range storage.entries {
+		if entry.isExpired(now) {
+			delete(storage.entries, key)
+		}
+	}
+	storage.entriesMutex.Unlock()
+}
+
+// applies the operations of a batch at once, so concurrent readers see either none or all of them
+func (storage *memoryStorage) apply(operations []*batchOperation) {
+	now := time.Now()
+
+	storage.entriesMutex.Lock()
+	for _, operation := range operations {
+		if operation.delete {
+			delete(storage.entries, string(operation.key))
+
+			continue
+		}
+
+		entry := &memoryEntry{
+			key:   operation.key,
+			value: operation.value,
+		}
+		if operation.ttl != 0 {
+			entry.expiryTime = now.Add(operation.ttl)
+		}
+		storage.entries[string(operation.key)] = entry
+	}
+	storage.entriesMutex.Unlock()
+}
+
+func (storage *memoryStorage) clear() {
+	storage.entriesMutex.Lock()
+	storage.entries = make(map[string]*memoryEntry)
+	storage.entriesMutex.Unlock()
+}
+
+func (entry *memoryEntry) isExpired(now time.Time) bool {
+	return !entry.expiryTime.IsZero() && !now.Before(entry.expiryTime)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
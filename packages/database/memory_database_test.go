@@ -0,0 +1,84 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/parameter"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestMemoryDatabase(t *testing.T) {
+	parameter.NodeConfig.Set(CFG_BACKEND, BACKEND_MEMORY)
+	defer Close()
+
+	db, err := Get("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherDb, err := Get("testOther")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.Set([]byte("b"), []byte("2")); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Set([]byte("a1"), []byte("1")); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Set([]byte("a2"), []byte("3")); err != nil {
+		t.Fatal(err)
+	}
+	if err := otherDb.Set([]byte("a3"), []byte("4")); err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := db.Get([]byte("b"))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, value, []byte("2"))
+
+	_, err = db.Get([]byte("a3"))
+	assert.Equal(t, err, ErrKeyNotFound)
+
+	// iteration is ordered and limited to the own prefix
+	assert.Equal(t, collectKeys(t, db.ForEach), []string{"a1", "a2", "b"})
+	assert.Equal(t, collectKeys(t, func(consumer func([]byte, []byte)) error {
+		return db.ForEachWithPrefix([]byte("a"), consumer)
+	}), []string{"a1", "a2"})
+
+	// entries can be deleted while iterating
+	if err := db.ForEach(func(key []byte, value []byte) {
+		if err := db.Delete(key); err != nil {
+			t.Error(err)
+		}
+	}); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, len(collectKeys(t, db.ForEach)), 0)
+	assert.Equal(t, collectKeys(t, otherDb.ForEach), []string{"a3"})
+
+	// entries with a ttl disappear after it expired
+	if err := db.SetWithTTL([]byte("ttl"), []byte("5"), 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	contains, _ := db.Contains([]byte("ttl"))
+	assert.Equal(t, contains, true)
+
+	time.Sleep(20 * time.Millisecond)
+
+	contains, _ = db.Contains([]byte("ttl"))
+	assert.Equal(t, contains, false)
+	assert.Equal(t, len(collectKeys(t, db.ForEach)), 0)
+}
+
+func collectKeys(t *testing.T, forEach func(consumer func([]byte, []byte)) error) []string {
+	result := make([]string, 0)
+	if err := forEach(func(key []byte, value []byte) {
+		result = append(result, string(key))
+	}); err != nil {
+		t.Error(err)
+	}
+
+	return result
+}
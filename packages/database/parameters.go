@@ -6,8 +6,15 @@ import (
 
 const (
 	CFG_DIRECTORY = "database.directory"
+	CFG_BACKEND   = "database.backend"
+)
+
+const (
+	BACKEND_BADGER = "badger"
+	BACKEND_MEMORY = "memory"
 )
 
 func init() {
 	flag.String(CFG_DIRECTORY, "mainnetdb", "path to the database folder")
+	flag.String(CFG_BACKEND, BACKEND_BADGER, "storage backend of the database (\"badger\" or \"memory\")")
 }
@@ -211,6 +211,21 @@ func (cache *LRUCache) Get(key interface{}) (result interface{}) {
 	return
 }
 
+// Calls the consumer for every entry that is currently stored in the cache.
+// The entries are collected before the consumer is called, so it is safe to modify the cache in the consumer.
+func (cache *LRUCache) ForEach(consumer func(key interface{}, value interface{})) {
+	cache.mutex.RLock()
+	elements := make([]lruCacheElement, 0, cache.size)
+	for _, entry := range cache.directory {
+		elements = append(elements, *entry.GetValue().(*lruCacheElement))
+	}
+	cache.mutex.RUnlock()
+
+	for _, element := range elements {
+		consumer(element.key, element.value)
+	}
+}
+
 func (cache *LRUCache) GetCapacity() int {
 	cache.mutex.RLock()
 	defer cache.mutex.RUnlock()
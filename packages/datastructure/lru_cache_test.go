@@ -102,3 +102,20 @@ func TestLRUCache_ComputeIfPresent(t *testing.T) {
 		t.Error("cache was not updated correctly")
 	}
 }
+
+func TestLRUCache_ForEach(t *testing.T) {
+	cache := NewLRUCache(5)
+	cache.Set(1, 10)
+	cache.Set(2, 20)
+	cache.Set(3, 30)
+
+	sum := 0
+	cache.ForEach(func(key interface{}, value interface{}) {
+		sum += value.(int)
+
+		cache.Delete(key)
+	})
+	if sum != 60 || cache.GetSize() != 0 {
+		t.Error("cache was not iterated correctly")
+	}
+}
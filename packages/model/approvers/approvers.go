@@ -55,11 +55,18 @@ func (approvers *Approvers) GetHash() (result trinary.Trytes) {
 	return
 }
 
-func (approvers *Approvers) GetModified() bool {
-	return true
+func (approvers *Approvers) GetModified() (result bool) {
+	approvers.hashesMutex.RLock()
+	result = approvers.modified
+	approvers.hashesMutex.RUnlock()
+
+	return
 }
 
 func (approvers *Approvers) SetModified(modified bool) {
+	approvers.hashesMutex.Lock()
+	approvers.modified = modified
+	approvers.hashesMutex.Unlock()
 }
 
 func (approvers *Approvers) Marshal() (result []byte) {
@@ -27,7 +27,8 @@ type Bundle struct {
 
 func New(headTransactionHash trinary.Trytes) (result *Bundle) {
 	result = &Bundle{
-		hash: headTransactionHash,
+		hash:     headTransactionHash,
+		modified: true,
 	}
 
 	return
@@ -45,6 +46,8 @@ func (bundle *Bundle) SetHash(hash trinary.Trytes) {
 	bundle.hashMutex.Lock()
 	bundle.hash = hash
 	bundle.hashMutex.Unlock()
+
+	bundle.SetModified(true)
 }
 
 func (bundle *Bundle) GetTransactionHashes() (result []trinary.Trytes) {
@@ -59,6 +62,8 @@ func (bundle *Bundle) SetTransactionHashes(transactionHashes []trinary.Trytes) {
 	bundle.transactionHashesMutex.Lock()
 	bundle.transactionHashes = transactionHashes
 	bundle.transactionHashesMutex.Unlock()
+
+	bundle.SetModified(true)
 }
 
 func (bundle *Bundle) IsValueBundle() (result bool) {
@@ -73,6 +78,8 @@ func (bundle *Bundle) SetValueBundle(valueBundle bool) {
 	bundle.isValueBundleMutex.Lock()
 	bundle.isValueBundle = valueBundle
 	bundle.isValueBundleMutex.Unlock()
+
+	bundle.SetModified(true)
 }
 
 func (bundle *Bundle) GetBundleEssenceHash() (result trinary.Trytes) {
@@ -87,6 +94,8 @@ func (bundle *Bundle) SetBundleEssenceHash(bundleEssenceHash trinary.Trytes) {
 	bundle.bundleEssenceHashMutex.Lock()
 	bundle.bundleEssenceHash = bundleEssenceHash
 	bundle.bundleEssenceHashMutex.Unlock()
+
+	bundle.SetModified(true)
 }
 
 func (bundle *Bundle) GetModified() (result bool) {
@@ -1,6 +1,7 @@
 package transactionmetadata
 
 import (
+	"encoding/binary"
 	"sync"
 	"time"
 
@@ -21,10 +22,18 @@ type TransactionMetadata struct {
 	receivedTimeMutex   sync.RWMutex
 	solid               bool
 	solidMutex          sync.RWMutex
+	solidificationTime  time.Time
+	solidificationMutex sync.RWMutex
+	approvalWeight      uint64
+	approvalWeightMutex sync.RWMutex
+	confirmed           bool
+	confirmedMutex      sync.RWMutex
 	liked               bool
 	likedMutex          sync.RWMutex
 	finalized           bool
 	finalizedMutex      sync.RWMutex
+	conflicting         bool
+	conflictingMutex    sync.RWMutex
 	modified            bool
 	modifiedMutex       sync.RWMutex
 }
@@ -34,8 +43,10 @@ func New(hash trinary.Trytes) *TransactionMetadata {
 		hash:         hash,
 		receivedTime: time.Now(),
 		solid:        false,
+		confirmed:    false,
 		liked:        false,
 		finalized:    false,
+		conflicting:  false,
 		modified:     true,
 	}
 }
@@ -140,6 +151,92 @@ func (metadata *TransactionMetadata) SetSolid(solid bool) bool {
 	return false
 }
 
+func (metadata *TransactionMetadata) GetSolidificationTime() time.Time {
+	metadata.solidificationMutex.RLock()
+	defer metadata.solidificationMutex.RUnlock()
+
+	return metadata.solidificationTime
+}
+
+func (metadata *TransactionMetadata) SetSolidificationTime(solidificationTime time.Time) {
+	metadata.solidificationMutex.RLock()
+	if metadata.solidificationTime != solidificationTime {
+		metadata.solidificationMutex.RUnlock()
+		metadata.solidificationMutex.Lock()
+		defer metadata.solidificationMutex.Unlock()
+		if metadata.solidificationTime != solidificationTime {
+			metadata.solidificationTime = solidificationTime
+
+			metadata.SetModified(true)
+		}
+	} else {
+		metadata.solidificationMutex.RUnlock()
+	}
+}
+
+// returns the amount of solid transactions that directly or indirectly approve the transaction
+func (metadata *TransactionMetadata) GetApprovalWeight() uint64 {
+	metadata.approvalWeightMutex.RLock()
+	defer metadata.approvalWeightMutex.RUnlock()
+
+	return metadata.approvalWeight
+}
+
+func (metadata *TransactionMetadata) SetApprovalWeight(approvalWeight uint64) {
+	metadata.approvalWeightMutex.RLock()
+	if metadata.approvalWeight != approvalWeight {
+		metadata.approvalWeightMutex.RUnlock()
+		metadata.approvalWeightMutex.Lock()
+		defer metadata.approvalWeightMutex.Unlock()
+		if metadata.approvalWeight != approvalWeight {
+			metadata.approvalWeight = approvalWeight
+
+			metadata.SetModified(true)
+		}
+	} else {
+		metadata.approvalWeightMutex.RUnlock()
+	}
+}
+
+// increases the approval weight by the given amount and returns the new value (supports concurrency)
+func (metadata *TransactionMetadata) IncreaseApprovalWeight(delta uint64) uint64 {
+	metadata.approvalWeightMutex.Lock()
+	defer metadata.approvalWeightMutex.Unlock()
+
+	metadata.approvalWeight += delta
+
+	metadata.SetModified(true)
+
+	return metadata.approvalWeight
+}
+
+func (metadata *TransactionMetadata) GetConfirmed() bool {
+	metadata.confirmedMutex.RLock()
+	defer metadata.confirmedMutex.RUnlock()
+
+	return metadata.confirmed
+}
+
+func (metadata *TransactionMetadata) SetConfirmed(confirmed bool) bool {
+	metadata.confirmedMutex.RLock()
+	if metadata.confirmed != confirmed {
+		metadata.confirmedMutex.RUnlock()
+		metadata.confirmedMutex.Lock()
+		defer metadata.confirmedMutex.Unlock()
+		if metadata.confirmed != confirmed {
+			metadata.confirmed = confirmed
+
+			metadata.SetModified(true)
+
+			return true
+		}
+	} else {
+		metadata.confirmedMutex.RUnlock()
+	}
+
+	return false
+}
+
 func (metadata *TransactionMetadata) GetLiked() bool {
 	metadata.likedMutex.RLock()
 	defer metadata.likedMutex.RUnlock()
@@ -186,6 +283,29 @@ func (metadata *TransactionMetadata) SetFinalized(finalized bool) {
 	}
 }
 
+func (metadata *TransactionMetadata) GetConflicting() bool {
+	metadata.conflictingMutex.RLock()
+	defer metadata.conflictingMutex.RUnlock()
+
+	return metadata.conflicting
+}
+
+func (metadata *TransactionMetadata) SetConflicting(conflicting bool) {
+	metadata.conflictingMutex.RLock()
+	if metadata.conflicting != conflicting {
+		metadata.conflictingMutex.RUnlock()
+		metadata.conflictingMutex.Lock()
+		defer metadata.conflictingMutex.Unlock()
+		if metadata.conflicting != conflicting {
+			metadata.conflicting = conflicting
+
+			metadata.SetModified(true)
+		}
+	} else {
+		metadata.conflictingMutex.RUnlock()
+	}
+}
+
 // returns true if the transaction contains unsaved changes (supports concurrency)
 func (metadata *TransactionMetadata) GetModified() bool {
 	metadata.modifiedMutex.RLock()
@@ -213,10 +333,18 @@ func (metadata *TransactionMetadata) Marshal() ([]byte, errors.IdentifiableError
 	defer metadata.receivedTimeMutex.RUnlock()
 	metadata.solidMutex.RLock()
 	defer metadata.solidMutex.RUnlock()
+	metadata.solidificationMutex.RLock()
+	defer metadata.solidificationMutex.RUnlock()
+	metadata.approvalWeightMutex.RLock()
+	defer metadata.approvalWeightMutex.RUnlock()
+	metadata.confirmedMutex.RLock()
+	defer metadata.confirmedMutex.RUnlock()
 	metadata.likedMutex.RLock()
 	defer metadata.likedMutex.RUnlock()
 	metadata.finalizedMutex.RLock()
 	defer metadata.finalizedMutex.RUnlock()
+	metadata.conflictingMutex.RLock()
+	defer metadata.conflictingMutex.RUnlock()
 
 	copy(marshaledMetadata[MARSHALED_HASH_START:MARSHALED_HASH_END], typeutils.StringToBytes(metadata.hash))
 
@@ -226,6 +354,14 @@ func (metadata *TransactionMetadata) Marshal() ([]byte, errors.IdentifiableError
 	}
 	copy(marshaledMetadata[MARSHALED_RECEIVED_TIME_START:MARSHALED_RECEIVED_TIME_END], marshaledReceivedTime)
 
+	marshaledSolidificationTime, err := metadata.solidificationTime.MarshalBinary()
+	if err != nil {
+		return nil, ErrMarshallFailed.Derive(err, "failed to marshal solidification time")
+	}
+	copy(marshaledMetadata[MARSHALED_SOLIDIFICATION_TIME_START:MARSHALED_SOLIDIFICATION_TIME_END], marshaledSolidificationTime)
+
+	binary.BigEndian.PutUint64(marshaledMetadata[MARSHALED_APPROVAL_WEIGHT_START:MARSHALED_APPROVAL_WEIGHT_END], metadata.approvalWeight)
+
 	var booleanFlags bitutils.BitMask
 	if metadata.solid {
 		booleanFlags = booleanFlags.SetFlag(0)
@@ -236,6 +372,12 @@ func (metadata *TransactionMetadata) Marshal() ([]byte, errors.IdentifiableError
 	if metadata.finalized {
 		booleanFlags = booleanFlags.SetFlag(2)
 	}
+	if metadata.conflicting {
+		booleanFlags = booleanFlags.SetFlag(3)
+	}
+	if metadata.confirmed {
+		booleanFlags = booleanFlags.SetFlag(4)
+	}
 	marshaledMetadata[MARSHALED_FLAGS_START] = byte(booleanFlags)
 
 	return marshaledMetadata, nil
@@ -248,10 +390,22 @@ func (metadata *TransactionMetadata) Unmarshal(data []byte) errors.IdentifiableE
 	defer metadata.receivedTimeMutex.Unlock()
 	metadata.solidMutex.Lock()
 	defer metadata.solidMutex.Unlock()
+	metadata.solidificationMutex.Lock()
+	defer metadata.solidificationMutex.Unlock()
+	metadata.approvalWeightMutex.Lock()
+	defer metadata.approvalWeightMutex.Unlock()
+	metadata.confirmedMutex.Lock()
+	defer metadata.confirmedMutex.Unlock()
 	metadata.likedMutex.Lock()
 	defer metadata.likedMutex.Unlock()
 	metadata.finalizedMutex.Lock()
 	defer metadata.finalizedMutex.Unlock()
+	metadata.conflictingMutex.Lock()
+	defer metadata.conflictingMutex.Unlock()
+
+	if len(data) < MARSHALED_TOTAL_SIZE {
+		return ErrUnmarshalFailed.Derive(errors.New("unmarshall failed"), "marshaled transaction metadata is too short")
+	}
 
 	metadata.hash = trinary.Trytes(typeutils.BytesToString(data[MARSHALED_HASH_START:MARSHALED_HASH_END]))
 
@@ -259,6 +413,12 @@ func (metadata *TransactionMetadata) Unmarshal(data []byte) errors.IdentifiableE
 		return ErrUnmarshalFailed.Derive(err, "could not unmarshal the received time")
 	}
 
+	if err := metadata.solidificationTime.UnmarshalBinary(data[MARSHALED_SOLIDIFICATION_TIME_START:MARSHALED_SOLIDIFICATION_TIME_END]); err != nil {
+		return ErrUnmarshalFailed.Derive(err, "could not unmarshal the solidification time")
+	}
+
+	metadata.approvalWeight = binary.BigEndian.Uint64(data[MARSHALED_APPROVAL_WEIGHT_START:MARSHALED_APPROVAL_WEIGHT_END])
+
 	booleanFlags := bitutils.BitMask(data[MARSHALED_FLAGS_START])
 	if booleanFlags.HasFlag(0) {
 		metadata.solid = true
@@ -269,6 +429,12 @@ func (metadata *TransactionMetadata) Unmarshal(data []byte) errors.IdentifiableE
 	if booleanFlags.HasFlag(2) {
 		metadata.finalized = true
 	}
+	if booleanFlags.HasFlag(3) {
+		metadata.conflicting = true
+	}
+	if booleanFlags.HasFlag(4) {
+		metadata.confirmed = true
+	}
 
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"io"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/iotaledger/hive.go/events"
@@ -15,6 +16,8 @@ type ManagedConnection struct {
 	readTimeout  time.Duration
 	writeTimeout time.Duration
 	closeOnce    sync.Once
+	bytesRead    uint64
+	bytesWritten uint64
 }
 
 func NewManagedConnection(conn net.Conn) *ManagedConnection {
@@ -42,6 +45,7 @@ func (this *ManagedConnection) Read(receiveBuffer []byte) (n int, err error) {
 		byteCount, err := this.Conn.Read(receiveBuffer)
 		if byteCount > 0 {
 			totalReadBytes += byteCount
+			atomic.AddUint64(&this.bytesRead, uint64(byteCount))
 
 			receivedData := make([]byte, byteCount)
 			copy(receivedData, receiveBuffer)
@@ -64,7 +68,20 @@ func (this *ManagedConnection) Write(data []byte) (n int, err error) {
 		return 0, err
 	}
 
-	return this.Conn.Write(data)
+	n, err = this.Conn.Write(data)
+	atomic.AddUint64(&this.bytesWritten, uint64(n))
+
+	return n, err
+}
+
+// Returns the amount of bytes that were read from the connection.
+func (this *ManagedConnection) BytesRead() uint64 {
+	return atomic.LoadUint64(&this.bytesRead)
+}
+
+// Returns the amount of bytes that were written to the connection.
+func (this *ManagedConnection) BytesWritten() uint64 {
+	return atomic.LoadUint64(&this.bytesWritten)
 }
 
 func (this *ManagedConnection) Close() error {
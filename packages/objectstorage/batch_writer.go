@@ -0,0 +1,175 @@
+package objectstorage
+
+import (
+	"sync"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/goshimmer/packages/typeutils"
+)
+
+// region public api ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Writes all modified objects of the given storages to the database (in a single batch).
+func Flush(storages ...*ObjectStorage) errors.IdentifiableError {
+	cachedObjects := make([]*CachedObject, 0)
+	for _, storage := range storages {
+		cachedObjects = append(cachedObjects, storage.collectModifiedObjects()...)
+	}
+
+	return writeObjects(cachedObjects)
+}
+
+// Writes the given objects (which can belong to different storages) in a single batch, so either all or none of their
+// changes are persisted. The caller has to hold a reference to the objects.
+func Write(cachedObjects ...*CachedObject) errors.IdentifiableError {
+	return writeObjects(cachedObjects)
+}
+
+// Blocks until the objects that were queued for the batch writer (because they got evicted) are written.
+func WaitForPendingWrites() {
+	pendingWritesWaitGroup.Wait()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region batch writer /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Queues an evicted object for the batch writer. The writer is shared by all storages and collects all objects that are
+// queued at the same time, so the objects that belong together (i.e. a transaction, its metadata and its approvers)
+// usually end up in the same batch and the database sees fewer, larger commits.
+func scheduleWrite(cachedObject *CachedObject) {
+	pendingWritesWaitGroup.Add(1)
+
+	batchWriterStarted.Do(func() {
+		go runBatchWriter()
+	})
+
+	batchWriterQueue <- cachedObject
+}
+
+func runBatchWriter() {
+	for cachedObject := range batchWriterQueue {
+		cachedObjects := []*CachedObject{cachedObject}
+
+	collectQueuedObjects:
+		for len(cachedObjects) < BATCH_WRITER_MAX_BATCH_SIZE {
+			select {
+			case queuedObject := <-batchWriterQueue:
+				cachedObjects = append(cachedObjects, queuedObject)
+			default:
+				break collectQueuedObjects
+			}
+		}
+
+		if err := writeObjects(cachedObjects); err != nil {
+			for _, storage := range involvedStorages(cachedObjects) {
+				storage.Events.Error.Trigger(err)
+			}
+		}
+
+		pendingWritesWaitGroup.Add(-len(cachedObjects))
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utility methods //////////////////////////////////////////////////////////////////////////////////////////////
+
+// Writes the given objects in a single batch. The modified flag is reset before an object is marshaled, so changes that
+// are made while the batch is written mark the object as modified again. If the batch fails, the flags are restored.
+func writeObjects(cachedObjects []*CachedObject) errors.IdentifiableError {
+	batch := database.NewBatch()
+	writtenObjects := make([]*CachedObject, 0, len(cachedObjects))
+	for _, cachedObject := range cachedObjects {
+		if written, err := storeObjectInBatch(batch, cachedObject); err != nil {
+			markModified(writtenObjects)
+
+			return err
+		} else if written {
+			writtenObjects = append(writtenObjects, cachedObject)
+		}
+	}
+
+	if batch.Size() != 0 {
+		if err := batch.Commit(); err != nil {
+			markModified(writtenObjects)
+
+			return ErrDatabaseError.Derive(err, "failed to commit batch")
+		}
+	}
+
+	for _, cachedObject := range cachedObjects {
+		cachedObject.storage.onWritten(cachedObject)
+	}
+
+	return nil
+}
+
+func storeObjectInBatch(batch database.Batch, cachedObject *CachedObject) (bool, errors.IdentifiableError) {
+	storage := cachedObject.storage
+	if !cachedObject.object.GetModified() || storage.isDeleted(cachedObject) {
+		return false, nil
+	}
+
+	db, dbErr := storage.GetDatabase()
+	if dbErr != nil {
+		return false, dbErr
+	}
+
+	cachedObject.object.SetModified(false)
+
+	data, err := storage.marshal(cachedObject.object)
+	if err != nil {
+		cachedObject.object.SetModified(true)
+
+		return false, ErrMarshalFailed.Derive(err, "failed to marshal object "+cachedObject.key)
+	}
+
+	if err := batch.Set(db, typeutils.StringToBytes(cachedObject.key), data); err != nil {
+		cachedObject.object.SetModified(true)
+
+		return false, ErrDatabaseError.Derive(err, "failed to store object "+cachedObject.key)
+	}
+
+	return true, nil
+}
+
+// marks the objects of a failed batch as modified again, so they are written by the next flush
+func markModified(cachedObjects []*CachedObject) {
+	for _, cachedObject := range cachedObjects {
+		cachedObject.object.SetModified(true)
+	}
+}
+
+func involvedStorages(cachedObjects []*CachedObject) []*ObjectStorage {
+	result := make([]*ObjectStorage, 0)
+	seenStorages := make(map[*ObjectStorage]bool)
+	for _, cachedObject := range cachedObjects {
+		if !seenStorages[cachedObject.storage] {
+			seenStorages[cachedObject.storage] = true
+
+			result = append(result, cachedObject.storage)
+		}
+	}
+
+	return result
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region constants and variables //////////////////////////////////////////////////////////////////////////////////////
+
+var batchWriterQueue = make(chan *CachedObject, BATCH_WRITER_QUEUE_SIZE)
+
+var batchWriterStarted sync.Once
+
+// keeps track of the asynchronous database writes that are triggered by cache evictions
+var pendingWritesWaitGroup sync.WaitGroup
+
+const (
+	BATCH_WRITER_QUEUE_SIZE     = 10000
+	BATCH_WRITER_MAX_BATCH_SIZE = 1000
+)
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,43 @@
+package objectstorage
+
+import (
+	"sync/atomic"
+)
+
+// CachedObject wraps an object of an ObjectStorage. Every reference that is obtained by ObjectStorage.Load or
+// ObjectStorage.Store has to be released again. As long as an object is referenced (or has pending changes), it stays in
+// memory even if it gets evicted from the cache, so all users of a key always share the same instance.
+type CachedObject struct {
+	storage        *ObjectStorage
+	key            string
+	object         StorableObject
+	referenceCount int32
+
+	// the following fields are protected by the detachedObjectsMutex of the storage
+	evicted bool
+	deleted bool
+}
+
+func (cachedObject *CachedObject) GetKey() string {
+	return cachedObject.key
+}
+
+func (cachedObject *CachedObject) Get() StorableObject {
+	return cachedObject.object
+}
+
+func (cachedObject *CachedObject) Release() {
+	if referenceCount := atomic.AddInt32(&cachedObject.referenceCount, -1); referenceCount == 0 {
+		cachedObject.storage.onRelease(cachedObject)
+	} else if referenceCount < 0 {
+		panic("the cached object was released more often than it was retained")
+	}
+}
+
+func (cachedObject *CachedObject) retain() {
+	atomic.AddInt32(&cachedObject.referenceCount, 1)
+}
+
+func (cachedObject *CachedObject) isReferenced() bool {
+	return atomic.LoadInt32(&cachedObject.referenceCount) > 0
+}
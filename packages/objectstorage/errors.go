@@ -0,0 +1,11 @@
+package objectstorage
+
+import (
+	"github.com/iotaledger/goshimmer/packages/errors"
+)
+
+var (
+	ErrDatabaseError   = errors.Wrap(errors.New("database error"), "failed to access the object storage")
+	ErrUnmarshalFailed = errors.Wrap(errors.New("unmarshall failed"), "the stored object is corrupted")
+	ErrMarshalFailed   = errors.Wrap(errors.New("marshal failed"), "the object could not be marshaled")
+)
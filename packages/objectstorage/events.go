@@ -0,0 +1,15 @@
+package objectstorage
+
+import (
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/hive.go/events"
+)
+
+type ObjectStorageEvents struct {
+	// is triggered when a background write of the storage failed
+	Error *events.Event
+}
+
+func errorCaller(handler interface{}, params ...interface{}) {
+	handler.(func(errors.IdentifiableError))(params[0].(errors.IdentifiableError))
+}
@@ -0,0 +1,342 @@
+package objectstorage
+
+import (
+	"sync"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/datastructure"
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/goshimmer/packages/typeutils"
+	"github.com/iotaledger/hive.go/events"
+)
+
+// ObjectStorage keeps the objects of a database in an LRU cache. Modified objects are written to the database when they
+// are evicted from the cache or when the storage is flushed.
+type ObjectStorage struct {
+	Events ObjectStorageEvents
+
+	databaseName         string
+	marshal              MarshalFunc
+	unmarshal            UnmarshalFunc
+	options              *ObjectStorageOptions
+	cache                *datastructure.LRUCache
+	detachedObjects      map[string]*CachedObject
+	detachedObjectsMutex sync.Mutex
+}
+
+func New(databaseName string, marshal MarshalFunc, unmarshal UnmarshalFunc, optionalOptions ...ObjectStorageOption) *ObjectStorage {
+	storage := &ObjectStorage{
+		Events: ObjectStorageEvents{
+			Error: events.NewEvent(errorCaller),
+		},
+		databaseName:    databaseName,
+		marshal:         marshal,
+		unmarshal:       unmarshal,
+		options:         DEFAULT_OBJECT_STORAGE_OPTIONS.Override(optionalOptions...),
+		detachedObjects: make(map[string]*CachedObject),
+	}
+
+	storage.cache = datastructure.NewLRUCache(storage.options.CacheSize, &datastructure.LRUCacheOptions{
+		EvictionCallback: storage.onEvict,
+	})
+
+	return storage
+}
+
+// Returns the database that the objects are persisted in.
+func (storage *ObjectStorage) GetDatabase() (database.Database, errors.IdentifiableError) {
+	if db, err := database.Get(storage.databaseName); err != nil {
+		return nil, ErrDatabaseError.Derive(err, "failed to open database "+storage.databaseName)
+	} else {
+		return db, nil
+	}
+}
+
+// Retrieves the object with the given key (from the cache or the database). If the object does not exist, the optional
+// computeIfAbsent callback is used to create it. The returned reference has to be released after it was used. If the
+// object neither exists nor was created, the result is nil.
+func (storage *ObjectStorage) Load(key string, computeIfAbsent ...func(key string) StorableObject) (*CachedObject, errors.IdentifiableError) {
+	for {
+		var err errors.IdentifiableError
+		cacheResult := storage.cache.ComputeIfAbsent(key, func() interface{} {
+			if cachedObject := storage.reattach(key); cachedObject != nil {
+				return cachedObject
+			}
+
+			object, loadErr := storage.loadFromDatabase(key)
+			if loadErr != nil {
+				err = loadErr
+
+				return nil
+			}
+
+			if object == nil && len(computeIfAbsent) >= 1 && computeIfAbsent[0] != nil {
+				if object = computeIfAbsent[0](key); !typeutils.IsInterfaceNil(object) {
+					object.SetModified(true)
+				}
+			}
+
+			if typeutils.IsInterfaceNil(object) {
+				return nil
+			}
+
+			return &CachedObject{storage: storage, key: key, object: object}
+		})
+
+		if err != nil || typeutils.IsInterfaceNil(cacheResult) {
+			return nil, err
+		}
+
+		// the object might have been evicted and dropped before we retained it - in that case we load it again
+		if cachedObject := cacheResult.(*CachedObject); storage.retain(cachedObject) {
+			return cachedObject, nil
+		}
+	}
+}
+
+// Adds the object to the storage (it replaces a previously stored object with the same key). The object is marked as
+// modified, so it gets persisted. The returned reference has to be released after it was used.
+func (storage *ObjectStorage) Store(key string, object StorableObject) *CachedObject {
+	object.SetModified(true)
+
+	cachedObject := &CachedObject{storage: storage, key: key, object: object}
+	cachedObject.retain()
+
+	storage.detachedObjectsMutex.Lock()
+	delete(storage.detachedObjects, key)
+	storage.detachedObjectsMutex.Unlock()
+
+	storage.cache.Set(key, cachedObject)
+
+	return cachedObject
+}
+
+func (storage *ObjectStorage) Contains(key string) (bool, errors.IdentifiableError) {
+	if storage.cache.Contains(key) {
+		return true, nil
+	}
+
+	storage.detachedObjectsMutex.Lock()
+	_, detached := storage.detachedObjects[key]
+	storage.detachedObjectsMutex.Unlock()
+	if detached {
+		return true, nil
+	}
+
+	db, err := storage.GetDatabase()
+	if err != nil {
+		return false, err
+	}
+
+	if contains, dbErr := db.Contains(typeutils.StringToBytes(key)); dbErr != nil {
+		return false, ErrDatabaseError.Derive(dbErr, "failed to check if the object exists")
+	} else {
+		return contains, nil
+	}
+}
+
+// Removes the object from the cache and the database. Pending changes of the object are discarded.
+func (storage *ObjectStorage) Delete(key string) errors.IdentifiableError {
+	if cachedObject := storage.cache.Get(key); cachedObject != nil {
+		storage.markDeleted(cachedObject.(*CachedObject))
+	}
+	storage.cache.Delete(key)
+
+	storage.detachedObjectsMutex.Lock()
+	if cachedObject, exists := storage.detachedObjects[key]; exists {
+		delete(storage.detachedObjects, key)
+		cachedObject.deleted = true
+		cachedObject.object.SetModified(false)
+	}
+	storage.detachedObjectsMutex.Unlock()
+
+	db, err := storage.GetDatabase()
+	if err != nil {
+		return err
+	}
+
+	if dbErr := db.Delete(typeutils.StringToBytes(key)); dbErr != nil {
+		return ErrDatabaseError.Derive(dbErr, "failed to delete object")
+	}
+
+	return nil
+}
+
+// Writes all modified objects of the storage to the database.
+func (storage *ObjectStorage) Flush() errors.IdentifiableError {
+	return Flush(storage)
+}
+
+// Iterates over all stored objects. Pending changes are written first, so the consumer also sees the objects that have
+// not been persisted yet. The iteration stops when the consumer returns false.
+func (storage *ObjectStorage) ForEach(consumer func(key []byte, object StorableObject) bool) errors.IdentifiableError {
+	if err := storage.Flush(); err != nil {
+		return err
+	}
+
+	db, err := storage.GetDatabase()
+	if err != nil {
+		return err
+	}
+
+	var unmarshalErr errors.IdentifiableError
+	if _, dbErr := db.Iterate(func(key []byte, value []byte) bool {
+		object, err := storage.unmarshal(key, value)
+		if err != nil {
+			unmarshalErr = ErrUnmarshalFailed.Derive(err, "failed to unmarshal object "+string(key))
+
+			return false
+		}
+
+		return consumer(key, object)
+	}); dbErr != nil {
+		return ErrDatabaseError.Derive(dbErr, "failed to iterate over the objects")
+	}
+
+	return unmarshalErr
+}
+
+// returns the modified objects of the cache and the objects that were evicted but not written yet
+func (storage *ObjectStorage) collectModifiedObjects() []*CachedObject {
+	result := make([]*CachedObject, 0)
+	storage.cache.ForEach(func(_ interface{}, value interface{}) {
+		if cachedObject := value.(*CachedObject); cachedObject.object.GetModified() {
+			result = append(result, cachedObject)
+		}
+	})
+
+	storage.detachedObjectsMutex.Lock()
+	for _, cachedObject := range storage.detachedObjects {
+		if cachedObject.object.GetModified() {
+			result = append(result, cachedObject)
+		}
+	}
+	storage.detachedObjectsMutex.Unlock()
+
+	return result
+}
+
+func (storage *ObjectStorage) loadFromDatabase(key string) (StorableObject, errors.IdentifiableError) {
+	db, err := storage.GetDatabase()
+	if err != nil {
+		return nil, err
+	}
+
+	data, dbErr := db.Get(typeutils.StringToBytes(key))
+	if dbErr != nil {
+		if dbErr == database.ErrKeyNotFound {
+			return nil, nil
+		}
+
+		return nil, ErrDatabaseError.Derive(dbErr, "failed to retrieve object")
+	}
+
+	object, unmarshalErr := storage.unmarshal(typeutils.StringToBytes(key), data)
+	if unmarshalErr != nil {
+		return nil, ErrUnmarshalFailed.Derive(unmarshalErr, "failed to unmarshal object "+key)
+	}
+
+	return object, nil
+}
+
+// region reference counting ///////////////////////////////////////////////////////////////////////////////////////////
+
+// moves an evicted object back into the cache
+func (storage *ObjectStorage) reattach(key string) *CachedObject {
+	storage.detachedObjectsMutex.Lock()
+	defer storage.detachedObjectsMutex.Unlock()
+
+	cachedObject, exists := storage.detachedObjects[key]
+	if !exists {
+		return nil
+	}
+
+	delete(storage.detachedObjects, key)
+	cachedObject.evicted = false
+
+	return cachedObject
+}
+
+// retains the object unless it was evicted and dropped in the meantime
+func (storage *ObjectStorage) retain(cachedObject *CachedObject) bool {
+	storage.detachedObjectsMutex.Lock()
+	defer storage.detachedObjectsMutex.Unlock()
+
+	if cachedObject.evicted && storage.detachedObjects[cachedObject.key] != cachedObject {
+		return false
+	}
+
+	cachedObject.retain()
+
+	return true
+}
+
+// Keeps evicted objects in memory as long as they are referenced or have changes that are not written yet. The changes
+// of referenced objects are written once they are released, so the users of an object can still write it together with
+// related objects.
+func (storage *ObjectStorage) onEvict(_ interface{}, value interface{}) {
+	cachedObject := value.(*CachedObject)
+
+	storage.detachedObjectsMutex.Lock()
+	cachedObject.evicted = true
+	if cachedObject.deleted {
+		storage.detachedObjectsMutex.Unlock()
+
+		return
+	}
+
+	modified := cachedObject.object.GetModified()
+	referenced := cachedObject.isReferenced()
+	if modified || referenced {
+		storage.detachedObjects[cachedObject.key] = cachedObject
+	}
+	storage.detachedObjectsMutex.Unlock()
+
+	if modified && !referenced {
+		scheduleWrite(cachedObject)
+	}
+}
+
+func (storage *ObjectStorage) onRelease(cachedObject *CachedObject) {
+	storage.detachedObjectsMutex.Lock()
+	if !cachedObject.evicted || storage.detachedObjects[cachedObject.key] != cachedObject {
+		storage.detachedObjectsMutex.Unlock()
+
+		return
+	}
+
+	modified := cachedObject.object.GetModified()
+	if !modified {
+		delete(storage.detachedObjects, cachedObject.key)
+	}
+	storage.detachedObjectsMutex.Unlock()
+
+	if modified {
+		scheduleWrite(cachedObject)
+	}
+}
+
+// drops a detached object once its changes are persisted and nobody references it anymore
+func (storage *ObjectStorage) onWritten(cachedObject *CachedObject) {
+	storage.detachedObjectsMutex.Lock()
+	if cachedObject.evicted && storage.detachedObjects[cachedObject.key] == cachedObject && !cachedObject.isReferenced() && !cachedObject.object.GetModified() {
+		delete(storage.detachedObjects, cachedObject.key)
+	}
+	storage.detachedObjectsMutex.Unlock()
+}
+
+func (storage *ObjectStorage) markDeleted(cachedObject *CachedObject) {
+	storage.detachedObjectsMutex.Lock()
+	cachedObject.deleted = true
+	cachedObject.object.SetModified(false)
+	storage.detachedObjectsMutex.Unlock()
+}
+
+func (storage *ObjectStorage) isDeleted(cachedObject *CachedObject) bool {
+	storage.detachedObjectsMutex.Lock()
+	defer storage.detachedObjectsMutex.Unlock()
+
+	return cachedObject.deleted
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,155 @@
+package objectstorage
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/hive.go/parameter"
+	"github.com/magiconair/properties/assert"
+)
+
+type testObject struct {
+	value    int
+	modified bool
+	mutex    sync.RWMutex
+}
+
+func (object *testObject) GetValue() int {
+	object.mutex.RLock()
+	defer object.mutex.RUnlock()
+
+	return object.value
+}
+
+func (object *testObject) SetValue(value int) {
+	object.mutex.Lock()
+	defer object.mutex.Unlock()
+
+	object.value = value
+}
+
+func (object *testObject) GetModified() bool {
+	object.mutex.RLock()
+	defer object.mutex.RUnlock()
+
+	return object.modified
+}
+
+func (object *testObject) SetModified(modified bool) {
+	object.mutex.Lock()
+	defer object.mutex.Unlock()
+
+	object.modified = modified
+}
+
+func marshalTestObject(object StorableObject) ([]byte, error) {
+	return []byte(strconv.Itoa(object.(*testObject).GetValue())), nil
+}
+
+func unmarshalTestObject(key []byte, data []byte) (StorableObject, error) {
+	value, err := strconv.Atoi(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return &testObject{value: value}, nil
+}
+
+func TestObjectStorage(t *testing.T) {
+	parameter.NodeConfig.Set(database.CFG_BACKEND, database.BACKEND_MEMORY)
+	defer database.Close()
+
+	storage := New("objectStorageTest", marshalTestObject, unmarshalTestObject, CacheSize(2))
+
+	storage.Store("a", &testObject{value: 1}).Release()
+
+	// unknown objects are only created if a callback is given
+	unknownObject, err := storage.Load("b")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, unknownObject == nil, true)
+	createdObject, err := storage.Load("b", func(key string) StorableObject {
+		return &testObject{value: 2}
+	})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, createdObject.Get().(*testObject).GetValue(), 2)
+	createdObject.Release()
+
+	// a referenced object survives the eviction and is shared with later loads
+	cachedObject, err := storage.Load("a")
+	assert.Equal(t, err, nil)
+	cachedObject.Get().(*testObject).SetValue(10)
+	storage.Store("c", &testObject{value: 3}).Release()
+	storage.Store("d", &testObject{value: 4}).Release()
+
+	reloadedObject, err := storage.Load("a")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, reloadedObject == cachedObject, true)
+	reloadedObject.Release()
+	cachedObject.Release()
+
+	// flushing writes all pending changes
+	if err := storage.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	WaitForPendingWrites()
+
+	values := make(map[string]int)
+	if err := storage.ForEach(func(key []byte, object StorableObject) bool {
+		values[string(key)] = object.(*testObject).GetValue()
+
+		return true
+	}); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, values, map[string]int{"a": 10, "b": 2, "c": 3, "d": 4})
+
+	// deleted objects are removed from the cache and the database
+	if err := storage.Delete("a"); err != nil {
+		t.Fatal(err)
+	}
+	contains, err := storage.Contains("a")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, contains, false)
+	contains, err = storage.Contains("d")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, contains, true)
+}
+
+func TestFlushKeepsConcurrentChanges(t *testing.T) {
+	parameter.NodeConfig.Set(database.CFG_BACKEND, database.BACKEND_MEMORY)
+	defer database.Close()
+
+	// the marshal function changes the object like a concurrent writer that modifies it while the batch is written
+	storage := New("flushTest", func(object StorableObject) ([]byte, error) {
+		if value := object.(*testObject).GetValue(); value < 0 {
+			return nil, errors.New("negative value")
+		} else if value == 1 {
+			object.(*testObject).SetValue(2)
+			object.SetModified(true)
+		}
+
+		return marshalTestObject(object)
+	}, unmarshalTestObject)
+
+	cachedObject := storage.Store("a", &testObject{value: 1})
+	defer cachedObject.Release()
+
+	if err := storage.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, cachedObject.Get().GetModified(), true)
+
+	if err := storage.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, cachedObject.Get().GetModified(), false)
+
+	// objects that could not be written stay modified
+	cachedObject.Get().(*testObject).SetValue(-1)
+	cachedObject.Get().SetModified(true)
+	assert.Equal(t, ErrMarshalFailed.Equals(storage.Flush()), true)
+	assert.Equal(t, cachedObject.Get().GetModified(), true)
+}
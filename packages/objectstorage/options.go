@@ -0,0 +1,27 @@
+package objectstorage
+
+var DEFAULT_OBJECT_STORAGE_OPTIONS = &ObjectStorageOptions{
+	CacheSize: 1000,
+}
+
+// Sets the amount of objects that are kept in memory.
+func CacheSize(cacheSize int) ObjectStorageOption {
+	return func(args *ObjectStorageOptions) {
+		args.CacheSize = cacheSize
+	}
+}
+
+type ObjectStorageOptions struct {
+	CacheSize int
+}
+
+func (options ObjectStorageOptions) Override(optionalOptions ...ObjectStorageOption) *ObjectStorageOptions {
+	result := &options
+	for _, option := range optionalOptions {
+		option(result)
+	}
+
+	return result
+}
+
+type ObjectStorageOption func(*ObjectStorageOptions)
@@ -0,0 +1,14 @@
+package objectstorage
+
+// StorableObject is the interface that the objects of an ObjectStorage have to implement. The modified flag tells the
+// storage if the object has changes that still need to be written to the database.
+type StorableObject interface {
+	GetModified() bool
+	SetModified(modified bool)
+}
+
+// Marshals the given object into the bytes that are written to the database.
+type MarshalFunc func(object StorableObject) ([]byte, error)
+
+// Creates an object from the bytes that were stored under the given key.
+type UnmarshalFunc func(key []byte, data []byte) (StorableObject, error)
@@ -0,0 +1,11 @@
+package pow
+
+import (
+	"github.com/iotaledger/goshimmer/packages/errors"
+)
+
+var (
+	ErrCancelled              = errors.Wrap(errors.New("pow error"), "the proof of work was cancelled")
+	ErrInvalidWeightMagnitude = errors.Wrap(errors.New("pow error"), "the weight magnitude is out of range")
+	ErrInvalidTrits           = errors.Wrap(errors.New("pow error"), "the trits contain invalid values")
+)
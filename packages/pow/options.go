@@ -0,0 +1,31 @@
+package pow
+
+import (
+	"runtime"
+)
+
+var DEFAULT_OPTIONS = &Options{
+	WorkerCount: runtime.NumCPU(),
+}
+
+// Sets the amount of goroutines that search for a nonce in parallel.
+func WorkerCount(workerCount int) Option {
+	return func(args *Options) {
+		args.WorkerCount = workerCount
+	}
+}
+
+type Options struct {
+	WorkerCount int
+}
+
+func (options Options) Override(optionalOptions ...Option) *Options {
+	result := &options
+	for _, option := range optionalOptions {
+		option(result)
+	}
+
+	return result
+}
+
+type Option func(*Options)
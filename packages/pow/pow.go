@@ -0,0 +1,203 @@
+package pow
+
+import (
+	"context"
+	"math/bits"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/iotaledger/goshimmer/packages/curl"
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/goshimmer/packages/model/meta_transaction"
+	"github.com/iotaledger/goshimmer/packages/model/value_transaction"
+	"github.com/iotaledger/goshimmer/packages/ternary"
+	"github.com/iotaledger/iota.go/trinary"
+)
+
+// region public api ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Searches a nonce that gives the transaction a hash with at least minWeightMagnitude trailing zero trits and stores it
+// in the transaction.
+func DoPoW(ctx context.Context, transaction *value_transaction.ValueTransaction, minWeightMagnitude int, optionalOptions ...Option) errors.IdentifiableError {
+	nonce, err := Search(ctx, transaction.GetTrits(), NONCE_OFFSET, value_transaction.NONCE_SIZE, minWeightMagnitude, optionalOptions...)
+	if err != nil {
+		return err
+	}
+
+	transaction.SetNonce(trinary.MustTritsToTrytes(nonce))
+
+	return nil
+}
+
+// Searches the nonce (the nonceSize trits at nonceOffset) that gives the trits a curl hash with at least
+// minWeightMagnitude trailing zero trits. Every worker hashes strconv.IntSize candidates at once using the binary coded
+// curl implementation. The search stops with ErrCancelled when the context is done.
+func Search(ctx context.Context, trits trinary.Trits, nonceOffset int, nonceSize int, minWeightMagnitude int, optionalOptions ...Option) (trinary.Trits, errors.IdentifiableError) {
+	if minWeightMagnitude < 0 || minWeightMagnitude > curl.CURLP81_HASH_LENGTH {
+		return nil, ErrInvalidWeightMagnitude.Derive(errors.Errorf("%d is not between 0 and %d", minWeightMagnitude, curl.CURLP81_HASH_LENGTH), "failed to search nonce")
+	}
+
+	options := DEFAULT_OPTIONS.Override(optionalOptions...)
+
+	encodedTrits, err := encodeTrits(trits)
+	if err != nil {
+		return nil, err
+	}
+
+	searchCtx, cancelSearch := context.WithCancel(ctx)
+
+	search := &nonceSearch{
+		ctx:                searchCtx,
+		encodedTrits:       encodedTrits,
+		nonceOffset:        nonceOffset,
+		nonceSize:          nonceSize,
+		minWeightMagnitude: minWeightMagnitude,
+		result:             make(chan trinary.Trits, 1),
+	}
+
+	// stop the remaining workers once the search is done
+	var workers sync.WaitGroup
+	defer func() {
+		cancelSearch()
+		workers.Wait()
+	}()
+
+	for i := 0; i < options.WorkerCount; i++ {
+		workers.Add(1)
+
+		go func() {
+			defer workers.Done()
+
+			search.run()
+		}()
+	}
+
+	select {
+	case nonce := <-search.result:
+		return nonce, nil
+	case <-ctx.Done():
+		return nil, ErrCancelled.Derive(ctx.Err(), "failed to search nonce")
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region nonce search /////////////////////////////////////////////////////////////////////////////////////////////////
+
+type nonceSearch struct {
+	ctx                context.Context
+	encodedTrits       ternary.BCTrits
+	nonceOffset        int
+	nonceSize          int
+	minWeightMagnitude int
+	nextBatch          uint64
+	result             chan trinary.Trits
+}
+
+func (search *nonceSearch) run() {
+	// every worker modifies the nonce of its own copy
+	candidates := ternary.BCTrits{
+		Lo: append([]uint{}, search.encodedTrits.Lo...),
+		Hi: append([]uint{}, search.encodedTrits.Hi...),
+	}
+	nonces := make([]trinary.Trits, strconv.IntSize)
+
+	for {
+		select {
+		case <-search.ctx.Done():
+			return
+		default:
+		}
+
+		// every candidate of every batch gets a different nonce
+		batch := atomic.AddUint64(&search.nextBatch, 1) - 1
+		for lane := range nonces {
+			nonces[lane] = search.nonce(batch*strconv.IntSize + uint64(lane))
+		}
+		search.encodeNonces(candidates, nonces)
+
+		bctCurl := curl.NewBCTCurl(curl.CURLP81_HASH_LENGTH, curl.CURLP81_ROUNDS, strconv.IntSize)
+		bctCurl.Absorb(candidates)
+		hash := bctCurl.Squeeze(curl.CURLP81_HASH_LENGTH)
+
+		// a lane is a match if all of the required trailing trits are zero (encoded as 11)
+		matches := ^uint(0)
+		for i := curl.CURLP81_HASH_LENGTH - search.minWeightMagnitude; i < curl.CURLP81_HASH_LENGTH; i++ {
+			matches &= hash.Lo[i] & hash.Hi[i]
+		}
+
+		if matches != 0 {
+			select {
+			case search.result <- nonces[bits.TrailingZeros(matches)]:
+			default:
+			}
+
+			return
+		}
+	}
+}
+
+// returns the balanced ternary representation of the given counter
+func (search *nonceSearch) nonce(counter uint64) trinary.Trits {
+	result := make(trinary.Trits, search.nonceSize)
+	copy(result, trinary.IntToTrits(int64(counter)))
+
+	return result
+}
+
+// writes the nonces into the lanes of the binary coded trits
+func (search *nonceSearch) encodeNonces(candidates ternary.BCTrits, nonces []trinary.Trits) {
+	for i := 0; i < search.nonceSize; i++ {
+		var lo, hi uint
+		for lane, nonce := range nonces {
+			switch nonce[i] {
+			case -1:
+				lo |= 1 << uint(lane)
+			case 1:
+				hi |= 1 << uint(lane)
+			default:
+				lo |= 1 << uint(lane)
+				hi |= 1 << uint(lane)
+			}
+		}
+
+		candidates.Lo[search.nonceOffset+i] = lo
+		candidates.Hi[search.nonceOffset+i] = hi
+	}
+}
+
+// copies the trits into all lanes of the binary coded trits
+func encodeTrits(trits trinary.Trits) (ternary.BCTrits, errors.IdentifiableError) {
+	result := ternary.BCTrits{
+		Lo: make([]uint, len(trits)),
+		Hi: make([]uint, len(trits)),
+	}
+
+	for i, trit := range trits {
+		switch trit {
+		case -1:
+			result.Lo[i] = ^uint(0)
+		case 0:
+			result.Lo[i] = ^uint(0)
+			result.Hi[i] = ^uint(0)
+		case 1:
+			result.Hi[i] = ^uint(0)
+		default:
+			return result, ErrInvalidTrits.Derive(errors.Errorf("invalid trit %d at index %d", trit, i), "failed to encode the trits")
+		}
+	}
+
+	return result, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region constants and variables //////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// position of the nonce of a value transaction in the trits of the transaction
+	NONCE_OFFSET = meta_transaction.DATA_OFFSET + value_transaction.NONCE_OFFSET
+)
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
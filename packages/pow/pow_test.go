@@ -0,0 +1,42 @@
+package pow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iotaledger/goshimmer/packages/model/value_transaction"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestDoPoW(t *testing.T) {
+	transaction := value_transaction.New()
+	transaction.SetValue(42)
+
+	if err := DoPoW(context.Background(), transaction, 7, WorkerCount(2)); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, transaction.GetWeightMagnitude() >= 7, true)
+}
+
+func TestDoPoWCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// the weight magnitude is unreachable, so only the cancellation stops the search
+	err := DoPoW(ctx, value_transaction.New(), 243)
+	assert.Equal(t, ErrCancelled.Equals(err), true)
+
+	err = DoPoW(context.Background(), value_transaction.New(), 244)
+	assert.Equal(t, ErrInvalidWeightMagnitude.Equals(err), true)
+}
+
+func BenchmarkDoPoW(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		transaction := value_transaction.New()
+		transaction.SetValue(int64(i))
+
+		if err := DoPoW(context.Background(), transaction, 9); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
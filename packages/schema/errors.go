@@ -0,0 +1,12 @@
+package schema
+
+import (
+	"github.com/iotaledger/goshimmer/packages/errors"
+)
+
+var (
+	ErrUnknownVersion   = errors.Wrap(errors.New("schema error"), "the database was written by a newer version of the node")
+	ErrMissingMigration = errors.Wrap(errors.New("schema error"), "no migration is registered for a schema version")
+	ErrMigrationFailed  = errors.Wrap(errors.New("schema error"), "failed to migrate the database")
+	ErrDatabaseError    = errors.Wrap(errors.New("database error"), "failed to access the schema version")
+)
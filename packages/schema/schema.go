@@ -0,0 +1,144 @@
+package schema
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/goshimmer/packages/settings"
+)
+
+// region public api ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Registers the migration that converts the database from version-1 to the given version. The migrations are usually
+// registered in the init functions of the packages that own the migrated data.
+func RegisterMigration(version uint32, description string, migrate func() error) {
+	migrationsMutex.Lock()
+	defer migrationsMutex.Unlock()
+
+	if _, exists := migrations[version]; exists {
+		panic("duplicate migration for schema version")
+	}
+
+	migrations[version] = &Migration{
+		Version:     version,
+		Description: description,
+		migrate:     migrate,
+	}
+}
+
+// Returns the schema version of the database. Databases that were written before the schema was versioned have version
+// 0.
+func GetVersion() (uint32, errors.IdentifiableError) {
+	marshaledVersion, err := settings.Get([]byte(SETTINGS_KEY_VERSION))
+	if err != nil {
+		if err == database.ErrKeyNotFound {
+			return 0, nil
+		}
+
+		return 0, ErrDatabaseError.Derive(err, "failed to read the schema version")
+	}
+
+	if len(marshaledVersion) != 4 {
+		return 0, ErrDatabaseError.Derive(errors.New("invalid length"), "the stored schema version is corrupted")
+	}
+
+	return binary.BigEndian.Uint32(marshaledVersion), nil
+}
+
+// Returns the migrations that are necessary to bring the database to the CURRENT_VERSION (in the order they have to be
+// executed). An error is returned if the database was written by a newer node.
+func GetPendingMigrations() ([]*Migration, errors.IdentifiableError) {
+	version, err := GetVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	if version > CURRENT_VERSION {
+		return nil, ErrUnknownVersion.Derive(errors.New("unknown schema version"), "the database has an unknown schema version")
+	}
+
+	migrationsMutex.Lock()
+	defer migrationsMutex.Unlock()
+
+	result := make([]*Migration, 0)
+	for targetVersion := version + 1; targetVersion <= CURRENT_VERSION; targetVersion++ {
+		migration, exists := migrations[targetVersion]
+		if !exists {
+			return nil, ErrMissingMigration.Derive(errors.New("missing migration"), "there is no migration to the schema version")
+		}
+
+		result = append(result, migration)
+	}
+
+	return result, nil
+}
+
+// Executes the pending migrations. The version is stored after every step, so an interrupted migration continues with
+// the step that failed.
+func Migrate(beforeStep func(migration *Migration)) errors.IdentifiableError {
+	pendingMigrations, err := GetPendingMigrations()
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range pendingMigrations {
+		if beforeStep != nil {
+			beforeStep(migration)
+		}
+
+		if err := migration.migrate(); err != nil {
+			return ErrMigrationFailed.Derive(err, migration.Description)
+		}
+
+		if err := setVersion(migration.Version); err != nil {
+			return err
+		}
+	}
+
+	// fresh databases have no pending migrations but need the version marker
+	return setVersion(CURRENT_VERSION)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utility methods //////////////////////////////////////////////////////////////////////////////////////////////
+
+func setVersion(version uint32) errors.IdentifiableError {
+	marshaledVersion := make([]byte, 4)
+	binary.BigEndian.PutUint32(marshaledVersion, version)
+
+	if err := settings.Set([]byte(SETTINGS_KEY_VERSION), marshaledVersion); err != nil {
+		return ErrDatabaseError.Derive(err, "failed to store the schema version")
+	}
+
+	return nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region types and interfaces /////////////////////////////////////////////////////////////////////////////////////////
+
+type Migration struct {
+	Version     uint32
+	Description string
+	migrate     func() error
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region constants and variables //////////////////////////////////////////////////////////////////////////////////////
+
+var migrations = make(map[uint32]*Migration)
+
+var migrationsMutex sync.Mutex
+
+const (
+	// the schema version that is written by this node (has to be increased with every migration)
+	CURRENT_VERSION = 2
+
+	SETTINGS_KEY_VERSION = "SCHEMA_VERSION"
+)
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,52 @@
+package schema
+
+import (
+	"os"
+	"testing"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/hive.go/parameter"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestMain(m *testing.M) {
+	parameter.FetchConfig(false)
+	parameter.NodeConfig.Set(database.CFG_BACKEND, database.BACKEND_MEMORY)
+	os.Exit(m.Run())
+}
+
+func TestMigrate(t *testing.T) {
+	executedMigrations := make([]uint32, 0)
+	for version := uint32(1); version <= CURRENT_VERSION; version++ {
+		migrationVersion := version
+		RegisterMigration(migrationVersion, "test migration", func() error {
+			executedMigrations = append(executedMigrations, migrationVersion)
+
+			return nil
+		})
+	}
+
+	// an unversioned database runs all migrations
+	if err := Migrate(nil); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, len(executedMigrations), CURRENT_VERSION)
+	assert.Equal(t, executedMigrations[0], uint32(1))
+
+	version, err := GetVersion()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, version, uint32(CURRENT_VERSION))
+
+	// migrations are only executed once
+	if err := Migrate(nil); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, len(executedMigrations), CURRENT_VERSION)
+
+	// databases of newer nodes are refused
+	if err := setVersion(CURRENT_VERSION + 1); err != nil {
+		t.Fatal(err)
+	}
+	_, err = GetPendingMigrations()
+	assert.Equal(t, ErrUnknownVersion.Equals(err), true)
+}
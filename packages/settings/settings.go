@@ -23,9 +23,13 @@ func Set(key []byte, value []byte) error {
 }
 
 func initDb() {
-	if db, err := database.Get("settings"); err != nil {
+	if db, err := database.Get(DATABASE_NAME); err != nil {
 		panic(err)
 	} else {
 		settingsDatabase = db
 	}
 }
+
+const (
+	DATABASE_NAME = "settings"
+)
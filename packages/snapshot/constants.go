@@ -0,0 +1,25 @@
+package snapshot
+
+const (
+	VERSION = byte(1)
+
+	MARSHALED_VERSION_START                  = 0
+	MARSHALED_SOLID_ENTRY_POINTS_COUNT_START = MARSHALED_VERSION_END
+	MARSHALED_TIPS_COUNT_START               = MARSHALED_SOLID_ENTRY_POINTS_COUNT_END
+	MARSHALED_BALANCES_COUNT_START           = MARSHALED_TIPS_COUNT_END
+	MARSHALED_ENTRIES_START                  = MARSHALED_BALANCES_COUNT_END
+
+	MARSHALED_VERSION_END                  = MARSHALED_VERSION_START + MARSHALED_VERSION_SIZE
+	MARSHALED_SOLID_ENTRY_POINTS_COUNT_END = MARSHALED_SOLID_ENTRY_POINTS_COUNT_START + MARSHALED_COUNT_SIZE
+	MARSHALED_TIPS_COUNT_END               = MARSHALED_TIPS_COUNT_START + MARSHALED_COUNT_SIZE
+	MARSHALED_BALANCES_COUNT_END           = MARSHALED_BALANCES_COUNT_START + MARSHALED_COUNT_SIZE
+
+	MARSHALED_VERSION_SIZE  = 1
+	MARSHALED_COUNT_SIZE    = 8
+	MARSHALED_HASH_SIZE     = 81
+	MARSHALED_BALANCE_SIZE  = 8
+	MARSHALED_CHECKSUM_SIZE = 32
+
+	MARSHALED_BALANCE_ENTRY_SIZE = MARSHALED_HASH_SIZE + MARSHALED_BALANCE_SIZE
+	MARSHALED_MIN_SIZE           = MARSHALED_ENTRIES_START + MARSHALED_CHECKSUM_SIZE
+)
@@ -0,0 +1,12 @@
+package snapshot
+
+import (
+	"github.com/iotaledger/goshimmer/packages/errors"
+)
+
+var (
+	ErrUnmarshalFailed     = errors.Wrap(errors.New("unmarshall failed"), "input data is corrupted")
+	ErrUnsupportedVersion  = errors.Wrap(errors.New("unmarshall failed"), "unsupported snapshot version")
+	ErrChecksumMismatch    = errors.Wrap(errors.New("unmarshall failed"), "checksum of the snapshot does not match")
+	ErrFileOperationFailed = errors.Wrap(errors.New("file error"), "failed to access the snapshot file")
+)
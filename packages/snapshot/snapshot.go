@@ -0,0 +1,157 @@
+package snapshot
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"io/ioutil"
+	"sort"
+	"strconv"
+
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/goshimmer/packages/typeutils"
+	"github.com/iotaledger/iota.go/trinary"
+)
+
+// Snapshot contains the state that is required to bootstrap a node without the transactions of the past.
+type Snapshot struct {
+	SolidEntryPoints []trinary.Trytes
+	Tips             []trinary.Trytes
+	Balances         map[trinary.Trytes]int64
+}
+
+func New() *Snapshot {
+	return &Snapshot{
+		SolidEntryPoints: make([]trinary.Trytes, 0),
+		Tips:             make([]trinary.Trytes, 0),
+		Balances:         make(map[trinary.Trytes]int64),
+	}
+}
+
+// region marshaling functions /////////////////////////////////////////////////////////////////////////////////////////
+
+func (snapshot *Snapshot) Marshal() (result []byte) {
+	result = make([]byte, MARSHALED_MIN_SIZE+(len(snapshot.SolidEntryPoints)+len(snapshot.Tips))*MARSHALED_HASH_SIZE+len(snapshot.Balances)*MARSHALED_BALANCE_ENTRY_SIZE)
+
+	result[MARSHALED_VERSION_START] = VERSION
+	binary.BigEndian.PutUint64(result[MARSHALED_SOLID_ENTRY_POINTS_COUNT_START:MARSHALED_SOLID_ENTRY_POINTS_COUNT_END], uint64(len(snapshot.SolidEntryPoints)))
+	binary.BigEndian.PutUint64(result[MARSHALED_TIPS_COUNT_START:MARSHALED_TIPS_COUNT_END], uint64(len(snapshot.Tips)))
+	binary.BigEndian.PutUint64(result[MARSHALED_BALANCES_COUNT_START:MARSHALED_BALANCES_COUNT_END], uint64(len(snapshot.Balances)))
+
+	offset := MARSHALED_ENTRIES_START
+	for _, transactionHash := range snapshot.SolidEntryPoints {
+		copy(result[offset:offset+MARSHALED_HASH_SIZE], typeutils.StringToBytes(transactionHash))
+
+		offset += MARSHALED_HASH_SIZE
+	}
+	for _, transactionHash := range snapshot.Tips {
+		copy(result[offset:offset+MARSHALED_HASH_SIZE], typeutils.StringToBytes(transactionHash))
+
+		offset += MARSHALED_HASH_SIZE
+	}
+
+	// sort the addresses so the same state always results in the same file
+	addresses := make([]string, 0, len(snapshot.Balances))
+	for address := range snapshot.Balances {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	for _, address := range addresses {
+		copy(result[offset:offset+MARSHALED_HASH_SIZE], typeutils.StringToBytes(address))
+		binary.BigEndian.PutUint64(result[offset+MARSHALED_HASH_SIZE:offset+MARSHALED_BALANCE_ENTRY_SIZE], uint64(snapshot.Balances[address]))
+
+		offset += MARSHALED_BALANCE_ENTRY_SIZE
+	}
+
+	checksum := sha256.Sum256(result[:offset])
+	copy(result[offset:], checksum[:])
+
+	return
+}
+
+func (snapshot *Snapshot) Unmarshal(data []byte) errors.IdentifiableError {
+	dataLen := len(data)
+
+	if dataLen < MARSHALED_MIN_SIZE {
+		return ErrUnmarshalFailed.Derive(errors.New("unmarshall failed"), "marshaled snapshot is too short")
+	}
+
+	if data[MARSHALED_VERSION_START] != VERSION {
+		return ErrUnsupportedVersion.Derive(errors.New("unmarshall failed"), "snapshot has version "+strconv.Itoa(int(data[MARSHALED_VERSION_START])))
+	}
+
+	solidEntryPointsCount := binary.BigEndian.Uint64(data[MARSHALED_SOLID_ENTRY_POINTS_COUNT_START:MARSHALED_SOLID_ENTRY_POINTS_COUNT_END])
+	tipsCount := binary.BigEndian.Uint64(data[MARSHALED_TIPS_COUNT_START:MARSHALED_TIPS_COUNT_END])
+	balancesCount := binary.BigEndian.Uint64(data[MARSHALED_BALANCES_COUNT_START:MARSHALED_BALANCES_COUNT_END])
+
+	// the counts are checked against the size of the data first, so the calculation of the expected size can not overflow
+	entriesSize := uint64(dataLen - MARSHALED_MIN_SIZE)
+	if solidEntryPointsCount > entriesSize/MARSHALED_HASH_SIZE || tipsCount > entriesSize/MARSHALED_HASH_SIZE || balancesCount > entriesSize/MARSHALED_BALANCE_ENTRY_SIZE {
+		return ErrUnmarshalFailed.Derive(errors.New("unmarshall failed"), "marshaled snapshot has an invalid size")
+	}
+
+	expectedSize := uint64(MARSHALED_MIN_SIZE) + (solidEntryPointsCount+tipsCount)*MARSHALED_HASH_SIZE + balancesCount*MARSHALED_BALANCE_ENTRY_SIZE
+	if uint64(dataLen) != expectedSize {
+		return ErrUnmarshalFailed.Derive(errors.New("unmarshall failed"), "marshaled snapshot has an invalid size")
+	}
+
+	checksumStart := dataLen - MARSHALED_CHECKSUM_SIZE
+	if checksum := sha256.Sum256(data[:checksumStart]); !bytes.Equal(checksum[:], data[checksumStart:]) {
+		return ErrChecksumMismatch.Derive(errors.New("unmarshall failed"), "the snapshot is corrupted")
+	}
+
+	offset := MARSHALED_ENTRIES_START
+
+	snapshot.SolidEntryPoints = make([]trinary.Trytes, solidEntryPointsCount)
+	for i := uint64(0); i < solidEntryPointsCount; i++ {
+		snapshot.SolidEntryPoints[i] = trinary.Trytes(string(data[offset : offset+MARSHALED_HASH_SIZE]))
+
+		offset += MARSHALED_HASH_SIZE
+	}
+
+	snapshot.Tips = make([]trinary.Trytes, tipsCount)
+	for i := uint64(0); i < tipsCount; i++ {
+		snapshot.Tips[i] = trinary.Trytes(string(data[offset : offset+MARSHALED_HASH_SIZE]))
+
+		offset += MARSHALED_HASH_SIZE
+	}
+
+	snapshot.Balances = make(map[trinary.Trytes]int64, balancesCount)
+	for i := uint64(0); i < balancesCount; i++ {
+		address := trinary.Trytes(string(data[offset : offset+MARSHALED_HASH_SIZE]))
+		snapshot.Balances[address] = int64(binary.BigEndian.Uint64(data[offset+MARSHALED_HASH_SIZE : offset+MARSHALED_BALANCE_ENTRY_SIZE]))
+
+		offset += MARSHALED_BALANCE_ENTRY_SIZE
+	}
+
+	return nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region file operations //////////////////////////////////////////////////////////////////////////////////////////////
+
+func (snapshot *Snapshot) WriteToFile(fileName string) errors.IdentifiableError {
+	if err := ioutil.WriteFile(fileName, snapshot.Marshal(), 0644); err != nil {
+		return ErrFileOperationFailed.Derive(err, "failed to write snapshot file "+fileName)
+	}
+
+	return nil
+}
+
+func FromFile(fileName string) (*Snapshot, errors.IdentifiableError) {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, ErrFileOperationFailed.Derive(err, "failed to read snapshot file "+fileName)
+	}
+
+	result := New()
+	if err := result.Unmarshal(data); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
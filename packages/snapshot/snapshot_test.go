@@ -0,0 +1,58 @@
+package snapshot
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+
+	"github.com/iotaledger/iota.go/trinary"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestSnapshot_MarshalUnmarshal(t *testing.T) {
+	hashA := trinary.Trytes("A9999999999999999999999999999999999999999999999999999999999999999999999999999999F")
+	hashB := trinary.Trytes("B9999999999999999999999999999999999999999999999999999999999999999999999999999999F")
+	hashC := trinary.Trytes("C9999999999999999999999999999999999999999999999999999999999999999999999999999999F")
+
+	snapshotTest := New()
+	snapshotTest.SolidEntryPoints = []trinary.Trytes{hashA, hashB}
+	snapshotTest.Tips = []trinary.Trytes{hashC}
+	snapshotTest.Balances[hashA] = 400
+	snapshotTest.Balances[hashB] = -12
+
+	var snapshotUnmarshaled Snapshot
+	if err := snapshotUnmarshaled.Unmarshal(snapshotTest.Marshal()); err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, snapshotUnmarshaled.SolidEntryPoints, snapshotTest.SolidEntryPoints, "solid entry points")
+	assert.Equal(t, snapshotUnmarshaled.Tips, snapshotTest.Tips, "tips")
+	assert.Equal(t, snapshotUnmarshaled.Balances, snapshotTest.Balances, "balances")
+}
+
+func TestSnapshot_UnmarshalCorrupted(t *testing.T) {
+	snapshotTest := New()
+	snapshotTest.Tips = []trinary.Trytes{trinary.Trytes("A9999999999999999999999999999999999999999999999999999999999999999999999999999999F")}
+
+	marshaledSnapshot := snapshotTest.Marshal()
+	marshaledSnapshot[MARSHALED_ENTRIES_START] = 'B'
+
+	var snapshotUnmarshaled Snapshot
+	err := snapshotUnmarshaled.Unmarshal(marshaledSnapshot)
+	assert.Equal(t, err != nil && ErrChecksumMismatch.Equals(err), true, "checksum mismatch should be detected")
+}
+
+func TestSnapshot_UnmarshalOverflowingCounts(t *testing.T) {
+	// the sum of the counts overflows, so the expected size matches the size of an empty snapshot
+	marshaledSnapshot := New().Marshal()
+	binary.BigEndian.PutUint64(marshaledSnapshot[MARSHALED_SOLID_ENTRY_POINTS_COUNT_START:MARSHALED_SOLID_ENTRY_POINTS_COUNT_END], 1<<63)
+	binary.BigEndian.PutUint64(marshaledSnapshot[MARSHALED_TIPS_COUNT_START:MARSHALED_TIPS_COUNT_END], 1<<63)
+
+	checksumStart := len(marshaledSnapshot) - MARSHALED_CHECKSUM_SIZE
+	checksum := sha256.Sum256(marshaledSnapshot[:checksumStart])
+	copy(marshaledSnapshot[checksumStart:], checksum[:])
+
+	var snapshotUnmarshaled Snapshot
+	err := snapshotUnmarshaled.Unmarshal(marshaledSnapshot)
+	assert.Equal(t, err != nil && ErrUnmarshalFailed.Equals(err), true, "invalid counts should be detected")
+}
@@ -1,12 +1,14 @@
 package transactionspammer
 
 import (
+	"context"
 	"sync"
 	"time"
 
 	"github.com/iotaledger/goshimmer/plugins/gossip"
 
 	"github.com/iotaledger/goshimmer/packages/model/value_transaction"
+	"github.com/iotaledger/goshimmer/packages/pow"
 	"github.com/iotaledger/goshimmer/plugins/tipselection"
 	"github.com/iotaledger/hive.go/daemon"
 )
@@ -27,6 +29,18 @@ func Start(tps uint) {
 
 		func(shutdownSignal chan int) {
 			daemon.BackgroundWorker("Transaction Spammer", func() {
+				// aborts a running proof-of-work when the spammer is stopped
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				go func() {
+					select {
+					case <-daemon.ShutdownSignal:
+					case <-shutdownSignal:
+					}
+
+					cancel()
+				}()
+
 				for {
 					start := time.Now()
 					totalSentCounter := int64(0)
@@ -50,6 +64,10 @@ func Start(tps uint) {
 							tx.SetBranchTransactionHash(tipselection.GetRandomTip())
 							tx.SetTrunkTransactionHash(tipselection.GetRandomTip())
 
+							if err := pow.DoPoW(ctx, tx, gossip.GetMinWeightMagnitude()); err != nil {
+								return
+							}
+
 							gossip.Events.ReceiveTransaction.Trigger(tx.MetaTransaction)
 
 							if sentCounter >= tps {
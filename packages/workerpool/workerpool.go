@@ -48,6 +48,33 @@ func (wp *WorkerPool) Submit(params ...interface{}) (result chan interface{}) {
 	return
 }
 
+// Submits the task only if the queue of the pool has room for it (the result is closed immediately and added is false if
+// the task was dropped).
+func (wp *WorkerPool) TrySubmit(params ...interface{}) (result chan interface{}, added bool) {
+	result = make(chan interface{}, 1)
+
+	wp.mutex.RLock()
+
+	if wp.running {
+		select {
+		case wp.calls <- Task{
+			params:     params,
+			resultChan: result,
+		}:
+			added = true
+		default:
+		}
+	}
+
+	wp.mutex.RUnlock()
+
+	if !added {
+		close(result)
+	}
+
+	return
+}
+
 func (wp *WorkerPool) Start() {
 	wp.mutex.Lock()
 
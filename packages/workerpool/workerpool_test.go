@@ -1,10 +1,45 @@
 package workerpool
 
 import (
+	"runtime"
 	"sync"
 	"testing"
 )
 
+func TestTrySubmit(t *testing.T) {
+	blockWorker := make(chan struct{})
+	pool := New(func(task Task) {
+		<-blockWorker
+
+		task.Return(task.Param(0))
+	}, WorkerCount(1), QueueSize(1))
+	pool.Start()
+	defer pool.StopAndWait()
+
+	// the first task blocks the worker and the second one fills the queue
+	firstResult, added := pool.TrySubmit(1)
+	if !added {
+		t.Fatal("the first task was dropped")
+	}
+	for len(pool.calls) != 0 {
+		runtime.Gosched()
+	}
+
+	secondResult, added := pool.TrySubmit(2)
+	if !added {
+		t.Fatal("the second task was dropped")
+	}
+
+	if _, added := pool.TrySubmit(3); added {
+		t.Fatal("the task was added to the full queue")
+	}
+
+	close(blockWorker)
+	if <-firstResult != 1 || <-secondResult != 2 {
+		t.Fatal("the tasks returned the wrong results")
+	}
+}
+
 func Benchmark(b *testing.B) {
 	pool := New(func(task Task) {
 		task.Return(task.Param(0))
@@ -7,6 +7,7 @@ import (
 	"github.com/iotaledger/goshimmer/packages/database"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/instances/knownpeers"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/types/peer"
+	"github.com/iotaledger/goshimmer/plugins/tangle"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
@@ -48,17 +49,26 @@ func removePeer(p *peer.Peer) {
 	}
 }
 
+// restores the stored peers - entries that can not be unmarshaled are removed, since the autopeering finds the peers
+// again
 func loadPeers(plugin *node.Plugin) {
 	var count int
+	invalidKeys := make([][]byte, 0)
 
 	err := getDb().ForEach(func(key []byte, value []byte) {
 		peer, err := peer.Unmarshal(value)
 		if err != nil {
-			panic(err)
+			log.Warningf("Removing invalid peer from database: %s", err.Error())
+			invalidKeys = append(invalidKeys, append([]byte{}, key...))
+
+			return
 		}
 		// the peers are stored by identifier in the db
 		if !bytes.Equal(key, peer.GetIdentity().Identifier) {
-			panic("Invalid item in '" + peerDbName + "' database")
+			log.Warningf("Removing invalid item from '%s' database", peerDbName)
+			invalidKeys = append(invalidKeys, append([]byte{}, key...))
+
+			return
 		}
 
 		knownpeers.INSTANCE.AddOrUpdate(peer)
@@ -69,6 +79,12 @@ func loadPeers(plugin *node.Plugin) {
 		panic(err)
 	}
 
+	for _, key := range invalidKeys {
+		if err := getDb().Delete(key); err != nil {
+			panic(err)
+		}
+	}
+
 	log.Infof("Restored %d peers from database", count)
 }
 
@@ -78,13 +94,20 @@ func Configure(plugin *node.Plugin) {
 	loadPeers(plugin)
 
 	// subscribe to all known peers' events
-	knownpeers.INSTANCE.Events.Add.Attach(events.NewClosure(func(p *peer.Peer) {
-		storePeer(p)
-	}))
-	knownpeers.INSTANCE.Events.Update.Attach(events.NewClosure(func(p *peer.Peer) {
+	onStorePeer := events.NewClosure(func(p *peer.Peer) {
 		storePeer(p)
-	}))
-	knownpeers.INSTANCE.Events.Remove.Attach(events.NewClosure(func(p *peer.Peer) {
+	})
+	onRemovePeer := events.NewClosure(func(p *peer.Peer) {
 		removePeer(p)
-	}))
+	})
+	knownpeers.INSTANCE.Events.Add.Attach(onStorePeer)
+	knownpeers.INSTANCE.Events.Update.Attach(onStorePeer)
+	knownpeers.INSTANCE.Events.Remove.Attach(onRemovePeer)
+
+	// stop storing peers before the database is closed (detaching waits for the running handlers)
+	tangle.RegisterDatabaseWorker(func() {
+		knownpeers.INSTANCE.Events.Add.Detach(onStorePeer)
+		knownpeers.INSTANCE.Events.Update.Detach(onStorePeer)
+		knownpeers.INSTANCE.Events.Remove.Detach(onRemovePeer)
+	})
 }
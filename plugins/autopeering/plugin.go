@@ -52,7 +52,7 @@ func configureLogging(plugin *node.Plugin) {
 	}))
 	acceptedneighbors.INSTANCE.Events.Remove.Attach(events.NewClosure(func(p *peer.Peer) {
 		log.Debugf("accepted neighbor removed: %s / %s", p.GetAddress().String(), p.GetIdentity().StringIdentifier)
-		gossip.RemoveNeighbor(p.GetIdentity().StringIdentifier)
+		removeGossipNeighbor(p)
 	}))
 
 	chosenneighbors.INSTANCE.Events.Add.Attach(events.NewClosure(func(p *peer.Peer) {
@@ -61,7 +61,7 @@ func configureLogging(plugin *node.Plugin) {
 	}))
 	chosenneighbors.INSTANCE.Events.Remove.Attach(events.NewClosure(func(p *peer.Peer) {
 		log.Debugf("chosen neighbor removed: %s / %s", p.GetAddress().String(), p.GetIdentity().StringIdentifier)
-		gossip.RemoveNeighbor(p.GetIdentity().StringIdentifier)
+		removeGossipNeighbor(p)
 	}))
 
 	knownpeers.INSTANCE.Events.Add.Attach(events.NewClosure(func(p *peer.Peer) {
@@ -79,3 +79,10 @@ func configureLogging(plugin *node.Plugin) {
 		}
 	}))
 }
+
+// removes the neighbor from the gossip unless it was configured manually
+func removeGossipNeighbor(p *peer.Peer) {
+	if neighbor, exists := gossip.GetNeighbor(p.GetIdentity().StringIdentifier); exists && !neighbor.IsStatic() {
+		gossip.RemoveNeighbor(p.GetIdentity().StringIdentifier)
+	}
+}
@@ -1,11 +1,13 @@
 package saltmanager
 
 import (
+	"sync"
 	"time"
 
 	"github.com/iotaledger/goshimmer/packages/database"
 	"github.com/iotaledger/goshimmer/packages/settings"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/types/salt"
+	"github.com/iotaledger/goshimmer/plugins/tangle"
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/node"
 )
@@ -15,9 +17,21 @@ var (
 	PUBLIC_SALT  *salt.Salt
 )
 
+// prevents salt updates from writing to the database after it was closed
+var (
+	stopped      bool
+	stoppedMutex sync.Mutex
+)
+
 func Configure(plugin *node.Plugin) {
 	PRIVATE_SALT = createSalt(PRIVATE_SALT_SETTINGS_KEY, PRIVATE_SALT_LIFETIME, Events.UpdatePrivateSalt.Trigger)
 	PUBLIC_SALT = createSalt(PUBLIC_SALT_SETTINGS_KEY, PUBLIC_SALT_LIFETIME, Events.UpdatePublicSalt.Trigger)
+
+	tangle.RegisterDatabaseWorker(func() {
+		stoppedMutex.Lock()
+		stopped = true
+		stoppedMutex.Unlock()
+	})
 }
 
 func generateNewSalt(key []byte, lifetime time.Duration) *salt.Salt {
@@ -48,6 +62,13 @@ func getSalt(key []byte, lifetime time.Duration) *salt.Salt {
 }
 
 func updatePublicSalt(saltToUpdate *salt.Salt, settingsKey []byte, lifeSpan time.Duration, updateCallback func(params ...interface{})) {
+	stoppedMutex.Lock()
+	if stopped {
+		stoppedMutex.Unlock()
+
+		return
+	}
+
 	newSalt := salt.New(lifeSpan)
 
 	saltToUpdate.SetBytes(newSalt.GetBytes())
@@ -56,6 +77,7 @@ func updatePublicSalt(saltToUpdate *salt.Salt, settingsKey []byte, lifeSpan time
 	if err := settings.Set(settingsKey, saltToUpdate.Marshal()); err != nil {
 		panic(err)
 	}
+	stoppedMutex.Unlock()
 
 	updateCallback(saltToUpdate)
 
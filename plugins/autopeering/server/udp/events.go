@@ -4,7 +4,9 @@ import (
 	"net"
 
 	"github.com/iotaledger/goshimmer/plugins/autopeering/types/drop"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/types/opinion"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/types/ping"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/types/query"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/types/request"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/types/response"
 	"github.com/iotaledger/hive.go/events"
@@ -15,12 +17,16 @@ var Events = struct {
 	ReceivePing     *events.Event
 	ReceiveRequest  *events.Event
 	ReceiveResponse *events.Event
+	ReceiveQuery    *events.Event
+	ReceiveOpinion  *events.Event
 	Error           *events.Event
 }{
 	events.NewEvent(dropCaller),
 	events.NewEvent(pingCaller),
 	events.NewEvent(requestCaller),
 	events.NewEvent(responseCaller),
+	events.NewEvent(queryCaller),
+	events.NewEvent(opinionCaller),
 	events.NewEvent(errorCaller),
 }
 
@@ -36,6 +42,12 @@ func requestCaller(handler interface{}, params ...interface{}) {
 func responseCaller(handler interface{}, params ...interface{}) {
 	handler.(func(*response.Response))(params[0].(*response.Response))
 }
+func queryCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*query.Query))(params[0].(*query.Query))
+}
+func opinionCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*opinion.Opinion))(params[0].(*opinion.Opinion))
+}
 func errorCaller(handler interface{}, params ...interface{}) {
 	handler.(func(net.IP, error))(params[0].(net.IP), params[1].(error))
 }
@@ -7,7 +7,9 @@ import (
 	"github.com/iotaledger/goshimmer/packages/network/udp"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/parameters"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/types/drop"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/types/opinion"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/types/ping"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/types/query"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/types/request"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/types/response"
 	"github.com/iotaledger/hive.go/daemon"
@@ -18,7 +20,7 @@ import (
 	"github.com/pkg/errors"
 )
 
-var udpServer = udp.NewServer(int(math.Max(float64(request.MARSHALED_TOTAL_SIZE), float64(response.MARSHALED_TOTAL_SIZE))))
+var udpServer = udp.NewServer(int(math.Max(math.Max(float64(request.MARSHALED_TOTAL_SIZE), float64(response.MARSHALED_TOTAL_SIZE)), math.Max(float64(query.MARSHALED_TOTAL_SIZE), float64(opinion.MARSHALED_TOTAL_SIZE)))))
 var log = logger.NewLogger("Autopeering-UDPServer")
 
 func ConfigureServer(plugin *node.Plugin) {
@@ -100,6 +102,22 @@ func processReceivedData(addr *net.UDPAddr, data []byte) {
 
 			Events.ReceiveDrop.Trigger(drop)
 		}
+	case query.MARSHALED_PACKET_HEADER:
+		if query, err := query.Unmarshal(data); err != nil {
+			Events.Error.Trigger(addr.IP, err)
+		} else {
+			query.Issuer.SetAddress(addr.IP)
+
+			Events.ReceiveQuery.Trigger(query)
+		}
+	case opinion.MARSHALED_PACKET_HEADER:
+		if opinion, err := opinion.Unmarshal(data); err != nil {
+			Events.Error.Trigger(addr.IP, err)
+		} else {
+			opinion.Issuer.SetAddress(addr.IP)
+
+			Events.ReceiveOpinion.Trigger(opinion)
+		}
 	default:
 		Events.Error.Trigger(addr.IP, errors.New("invalid UDP peering packet from "+addr.IP.String()))
 	}
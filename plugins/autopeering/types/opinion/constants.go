@@ -0,0 +1,36 @@
+package opinion
+
+import (
+	"github.com/iotaledger/goshimmer/plugins/autopeering/types/peer"
+)
+
+const (
+	MARSHALED_PACKET_HEADER = 0x07
+
+	PACKET_HEADER_START              = 0
+	MARSHALED_ISSUER_START           = PACKET_HEADER_END
+	MARSHALED_TRANSACTION_HASH_START = MARSHALED_ISSUER_END
+	MARSHALED_VALUE_START            = MARSHALED_TRANSACTION_HASH_END
+	MARSHALED_SIGNATURE_START        = MARSHALED_VALUE_END
+
+	PACKET_HEADER_END              = PACKET_HEADER_START + PACKET_HEADER_SIZE
+	MARSHALED_ISSUER_END           = MARSHALED_ISSUER_START + MARSHALED_ISSUER_SIZE
+	MARSHALED_TRANSACTION_HASH_END = MARSHALED_TRANSACTION_HASH_START + MARSHALED_TRANSACTION_HASH_SIZE
+	MARSHALED_VALUE_END            = MARSHALED_VALUE_START + MARSHALED_VALUE_SIZE
+	MARSHALED_SIGNATURE_END        = MARSHALED_SIGNATURE_START + MARSHALED_SIGNATURE_SIZE
+
+	PACKET_HEADER_SIZE              = 1
+	MARSHALED_ISSUER_SIZE           = peer.MARSHALED_TOTAL_SIZE
+	MARSHALED_TRANSACTION_HASH_SIZE = 81
+	MARSHALED_VALUE_SIZE            = 1
+	MARSHALED_SIGNATURE_SIZE        = 65
+
+	MARSHALED_TOTAL_SIZE = MARSHALED_SIGNATURE_END
+)
+
+// the possible opinions about a transaction
+const (
+	VALUE_UNKNOWN = Value(0)
+	VALUE_LIKE    = Value(1)
+	VALUE_DISLIKE = Value(2)
+)
@@ -0,0 +1,8 @@
+package opinion
+
+import "github.com/pkg/errors"
+
+var (
+	ErrInvalidSignature = errors.New("invalid signature in opinion")
+	ErrMalformedOpinion = errors.New("malformed opinion")
+)
@@ -0,0 +1,78 @@
+package opinion
+
+import (
+	"bytes"
+
+	"github.com/iotaledger/goshimmer/packages/identity"
+	"github.com/iotaledger/goshimmer/packages/typeutils"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/saltmanager"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/types/peer"
+	"github.com/iotaledger/iota.go/trinary"
+)
+
+// Opinion is the answer to a query and contains the opinion of the issuer about a transaction.
+type Opinion struct {
+	Issuer          *peer.Peer
+	TransactionHash trinary.Trytes
+	Value           Value
+	Signature       [MARSHALED_SIGNATURE_SIZE]byte
+}
+
+type Value byte
+
+func Unmarshal(data []byte) (*Opinion, error) {
+	if data[0] != MARSHALED_PACKET_HEADER || len(data) != MARSHALED_TOTAL_SIZE {
+		return nil, ErrMalformedOpinion
+	}
+
+	opinion := &Opinion{}
+
+	if unmarshaledPeer, err := peer.Unmarshal(data[MARSHALED_ISSUER_START:MARSHALED_ISSUER_END]); err != nil {
+		return nil, err
+	} else {
+		opinion.Issuer = unmarshaledPeer
+	}
+	if err := saltmanager.CheckSalt(opinion.Issuer.GetSalt()); err != nil {
+		return nil, err
+	}
+
+	opinion.TransactionHash = trinary.Trytes(string(data[MARSHALED_TRANSACTION_HASH_START:MARSHALED_TRANSACTION_HASH_END]))
+
+	switch value := Value(data[MARSHALED_VALUE_START]); value {
+	case VALUE_UNKNOWN, VALUE_LIKE, VALUE_DISLIKE:
+		opinion.Value = value
+	default:
+		return nil, ErrMalformedOpinion
+	}
+
+	if issuer, err := identity.FromSignedData(data[:MARSHALED_SIGNATURE_START], data[MARSHALED_SIGNATURE_START:]); err != nil {
+		return nil, err
+	} else {
+		if !bytes.Equal(issuer.Identifier, opinion.Issuer.GetIdentity().Identifier) {
+			return nil, ErrInvalidSignature
+		}
+	}
+	copy(opinion.Signature[:], data[MARSHALED_SIGNATURE_START:MARSHALED_SIGNATURE_END])
+
+	return opinion, nil
+}
+
+func (opinion *Opinion) Marshal() []byte {
+	result := make([]byte, MARSHALED_TOTAL_SIZE)
+
+	result[PACKET_HEADER_START] = MARSHALED_PACKET_HEADER
+	copy(result[MARSHALED_ISSUER_START:MARSHALED_ISSUER_END], opinion.Issuer.Marshal())
+	copy(result[MARSHALED_TRANSACTION_HASH_START:MARSHALED_TRANSACTION_HASH_END], typeutils.StringToBytes(opinion.TransactionHash))
+	result[MARSHALED_VALUE_START] = byte(opinion.Value)
+	copy(result[MARSHALED_SIGNATURE_START:MARSHALED_SIGNATURE_END], opinion.Signature[:MARSHALED_SIGNATURE_SIZE])
+
+	return result
+}
+
+func (opinion *Opinion) Sign() {
+	if signature, err := opinion.Issuer.GetIdentity().Sign(opinion.Marshal()[:MARSHALED_SIGNATURE_START]); err != nil {
+		panic(err)
+	} else {
+		copy(opinion.Signature[:], signature)
+	}
+}
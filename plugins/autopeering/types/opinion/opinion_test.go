@@ -0,0 +1,39 @@
+package opinion
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/identity"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/types/peer"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/types/salt"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestOpinion_MarshalUnmarshal(t *testing.T) {
+	issuer := &peer.Peer{}
+	issuer.SetAddress(net.IPv4(127, 0, 0, 1))
+	issuer.SetIdentity(identity.GenerateRandomIdentity())
+	issuer.SetGossipPort(123)
+	issuer.SetPeeringPort(456)
+	issuer.SetSalt(salt.New(30 * time.Second))
+
+	opinion := &Opinion{
+		Issuer:          issuer,
+		TransactionHash: "A99999999999999999999999999999999999999999999999999999999999999999999999999999999",
+		Value:           VALUE_DISLIKE,
+	}
+	opinion.Sign()
+
+	unmarshaledOpinion, err := Unmarshal(opinion.Marshal())
+	if err != nil {
+		t.Error(err)
+
+		return
+	}
+
+	assert.Equal(t, unmarshaledOpinion.TransactionHash, opinion.TransactionHash)
+	assert.Equal(t, unmarshaledOpinion.Value, opinion.Value)
+	assert.Equal(t, unmarshaledOpinion.Issuer.GetIdentity().StringIdentifier, issuer.GetIdentity().StringIdentifier)
+}
@@ -0,0 +1,34 @@
+package query
+
+import (
+	"time"
+
+	"github.com/iotaledger/goshimmer/plugins/autopeering/types/peer"
+)
+
+const (
+	MARSHALED_PACKET_HEADER = 0x06
+
+	PACKET_HEADER_START              = 0
+	MARSHALED_ISSUER_START           = PACKET_HEADER_END
+	MARSHALED_TRANSACTION_HASH_START = MARSHALED_ISSUER_END
+	MARSHALED_ISSUING_TIME_START     = MARSHALED_TRANSACTION_HASH_END
+	MARSHALED_SIGNATURE_START        = MARSHALED_ISSUING_TIME_END
+
+	PACKET_HEADER_END              = PACKET_HEADER_START + PACKET_HEADER_SIZE
+	MARSHALED_ISSUER_END           = MARSHALED_ISSUER_START + MARSHALED_ISSUER_SIZE
+	MARSHALED_TRANSACTION_HASH_END = MARSHALED_TRANSACTION_HASH_START + MARSHALED_TRANSACTION_HASH_SIZE
+	MARSHALED_ISSUING_TIME_END     = MARSHALED_ISSUING_TIME_START + MARSHALED_ISSUING_TIME_SIZE
+	MARSHALED_SIGNATURE_END        = MARSHALED_SIGNATURE_START + MARSHALED_SIGNATURE_SIZE
+
+	PACKET_HEADER_SIZE              = 1
+	MARSHALED_ISSUER_SIZE           = peer.MARSHALED_TOTAL_SIZE
+	MARSHALED_TRANSACTION_HASH_SIZE = 81
+	MARSHALED_ISSUING_TIME_SIZE     = 8
+	MARSHALED_SIGNATURE_SIZE        = 65
+
+	MARSHALED_TOTAL_SIZE = MARSHALED_SIGNATURE_END
+
+	// queries that were issued longer ago (or further in the future) are rejected as stale
+	MAX_ISSUING_TIME_DIFFERENCE = 30 * time.Second
+)
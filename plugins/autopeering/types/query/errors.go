@@ -0,0 +1,9 @@
+package query
+
+import "github.com/pkg/errors"
+
+var (
+	ErrInvalidSignature = errors.New("invalid signature in query")
+	ErrMalformedQuery   = errors.New("malformed query")
+	ErrStaleQuery       = errors.New("stale query")
+)
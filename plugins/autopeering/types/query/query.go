@@ -0,0 +1,77 @@
+package query
+
+import (
+	"bytes"
+	"encoding/binary"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/identity"
+	"github.com/iotaledger/goshimmer/packages/typeutils"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/saltmanager"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/types/peer"
+	"github.com/iotaledger/iota.go/trinary"
+)
+
+// Query asks a peer for its opinion about a transaction. The signed issuing time allows the receiver to reject stale and
+// replayed queries.
+type Query struct {
+	Issuer          *peer.Peer
+	TransactionHash trinary.Trytes
+	IssuingTime     time.Time
+	Signature       [MARSHALED_SIGNATURE_SIZE]byte
+}
+
+func Unmarshal(data []byte) (*Query, error) {
+	if data[0] != MARSHALED_PACKET_HEADER || len(data) != MARSHALED_TOTAL_SIZE {
+		return nil, ErrMalformedQuery
+	}
+
+	query := &Query{}
+
+	if unmarshaledPeer, err := peer.Unmarshal(data[MARSHALED_ISSUER_START:MARSHALED_ISSUER_END]); err != nil {
+		return nil, err
+	} else {
+		query.Issuer = unmarshaledPeer
+	}
+	if err := saltmanager.CheckSalt(query.Issuer.GetSalt()); err != nil {
+		return nil, err
+	}
+
+	query.TransactionHash = trinary.Trytes(string(data[MARSHALED_TRANSACTION_HASH_START:MARSHALED_TRANSACTION_HASH_END]))
+
+	query.IssuingTime = time.Unix(0, int64(binary.BigEndian.Uint64(data[MARSHALED_ISSUING_TIME_START:MARSHALED_ISSUING_TIME_END])))
+	if timeDifference := time.Since(query.IssuingTime); timeDifference > MAX_ISSUING_TIME_DIFFERENCE || timeDifference < -MAX_ISSUING_TIME_DIFFERENCE {
+		return nil, ErrStaleQuery
+	}
+
+	if issuer, err := identity.FromSignedData(data[:MARSHALED_SIGNATURE_START], data[MARSHALED_SIGNATURE_START:]); err != nil {
+		return nil, err
+	} else {
+		if !bytes.Equal(issuer.Identifier, query.Issuer.GetIdentity().Identifier) {
+			return nil, ErrInvalidSignature
+		}
+	}
+	copy(query.Signature[:], data[MARSHALED_SIGNATURE_START:MARSHALED_SIGNATURE_END])
+
+	return query, nil
+}
+
+func (query *Query) Marshal() []byte {
+	result := make([]byte, MARSHALED_TOTAL_SIZE)
+
+	result[PACKET_HEADER_START] = MARSHALED_PACKET_HEADER
+	copy(result[MARSHALED_ISSUER_START:MARSHALED_ISSUER_END], query.Issuer.Marshal())
+	copy(result[MARSHALED_TRANSACTION_HASH_START:MARSHALED_TRANSACTION_HASH_END], typeutils.StringToBytes(query.TransactionHash))
+	binary.BigEndian.PutUint64(result[MARSHALED_ISSUING_TIME_START:MARSHALED_ISSUING_TIME_END], uint64(query.IssuingTime.UnixNano()))
+	copy(result[MARSHALED_SIGNATURE_START:MARSHALED_SIGNATURE_END], query.Signature[:MARSHALED_SIGNATURE_SIZE])
+
+	return result
+}
+
+func (query *Query) Sign() {
+	if signature, err := query.Issuer.GetIdentity().Sign(query.Marshal()[:MARSHALED_SIGNATURE_START]); err != nil {
+		panic(err)
+	} else {
+		copy(query.Signature[:], signature)
+	}
+}
@@ -0,0 +1,44 @@
+package query
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/identity"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/types/peer"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/types/salt"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestQuery_MarshalUnmarshal(t *testing.T) {
+	issuer := &peer.Peer{}
+	issuer.SetAddress(net.IPv4(127, 0, 0, 1))
+	issuer.SetIdentity(identity.GenerateRandomIdentity())
+	issuer.SetGossipPort(123)
+	issuer.SetPeeringPort(456)
+	issuer.SetSalt(salt.New(30 * time.Second))
+
+	query := &Query{
+		Issuer:          issuer,
+		TransactionHash: "A99999999999999999999999999999999999999999999999999999999999999999999999999999999",
+		IssuingTime:     time.Now(),
+	}
+	query.Sign()
+
+	unmarshaledQuery, err := Unmarshal(query.Marshal())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, unmarshaledQuery.TransactionHash, query.TransactionHash)
+	assert.Equal(t, unmarshaledQuery.IssuingTime.UnixNano(), query.IssuingTime.UnixNano())
+	assert.Equal(t, unmarshaledQuery.Issuer.GetIdentity().StringIdentifier, issuer.GetIdentity().StringIdentifier)
+
+	// stale queries are rejected
+	query.IssuingTime = time.Now().Add(-2 * MAX_ISSUING_TIME_DIFFERENCE)
+	query.Sign()
+
+	_, err = Unmarshal(query.Marshal())
+	assert.Equal(t, err, ErrStaleQuery)
+}
@@ -25,7 +25,7 @@ var WORKER_COUNT = runtime.NumCPU()
 
 func ProcessSolidBundleHead(headTransaction *value_transaction.ValueTransaction) errors.IdentifiableError {
 	// only process the bundle if we didn't process it, yet
-	_, err := tangle.GetBundle(headTransaction.GetHash(), func(headTransactionHash trinary.Trytes) (*bundle.Bundle, errors.IdentifiableError) {
+	cachedBundle, err := tangle.GetBundle(headTransaction.GetHash(), func(headTransactionHash trinary.Trytes) (*bundle.Bundle, errors.IdentifiableError) {
 		// abort if bundle syntax is wrong
 		if !headTransaction.IsHead() {
 			return nil, ErrProcessBundleFailed.Derive(errors.New("invalid parameter"), "transaction needs to be head of bundle")
@@ -55,7 +55,8 @@ func ProcessSolidBundleHead(headTransaction *value_transaction.ValueTransaction)
 			if dbErr != nil {
 				return nil, ErrProcessBundleFailed.Derive(dbErr, "failed to retrieve transaction metadata")
 			}
-			currentTransactionMetadata.SetBundleHeadHash(headTransactionHash)
+			currentTransactionMetadata.Unwrap().SetBundleHeadHash(headTransactionHash)
+			currentTransactionMetadata.Release()
 
 			// update value bundle flag
 			if !newBundle.IsValueBundle() && currentTransaction.GetValue() < 0 {
@@ -84,10 +85,14 @@ func ProcessSolidBundleHead(headTransaction *value_transaction.ValueTransaction)
 				fmt.Println(ErrProcessBundleFailed.Derive(errors.New("missing transaction "+currentTransaction.GetTrunkTransactionHash()), "failed to retrieve trunk while processing bundle"))
 				return nil, ErrProcessBundleFailed.Derive(err, "failed to retrieve trunk while processing bundle")
 			} else {
-				currentTransaction = nextTransaction
+				currentTransaction = nextTransaction.Unwrap()
+				nextTransaction.Release()
 			}
 		}
 	})
+	if cachedBundle != nil {
+		cachedBundle.Release()
+	}
 
 	return err
 }
@@ -1,11 +1,15 @@
 package bundleprocessor
 
 import (
+	"math/rand"
 	"os"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/iotaledger/goshimmer/packages/client"
+	"github.com/iotaledger/goshimmer/packages/database"
 	"github.com/iotaledger/goshimmer/packages/model/bundle"
 	"github.com/iotaledger/goshimmer/packages/model/value_transaction"
 	"github.com/iotaledger/goshimmer/plugins/tangle"
@@ -14,6 +18,7 @@ import (
 	"github.com/iotaledger/hive.go/node"
 	"github.com/iotaledger/hive.go/parameter"
 	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/trinary"
 	"github.com/magiconair/properties/assert"
 )
 
@@ -46,6 +51,7 @@ func BenchmarkValidateSignatures(b *testing.B) {
 
 func TestMain(m *testing.M) {
 	parameter.FetchConfig(false)
+	parameter.NodeConfig.Set(database.CFG_BACKEND, database.BACKEND_MEMORY)
 	os.Exit(m.Run())
 }
 
@@ -148,3 +154,116 @@ func TestProcessSolidBundleHead_Value(t *testing.T) {
 	// shutdown test node
 	node.Shutdown()
 }
+
+func TestDetectConflicts(t *testing.T) {
+	// start a test node
+	node.Start(tangle.PLUGIN, PLUGIN)
+
+	// use a fresh seed so the spends of previous test runs do not interfere
+	conflictSeed := client.NewSeed(randomTrytes(81), consts.SecurityLevelMedium)
+
+	generatedBundles := make([]*client.Bundle, 2)
+	for i := range generatedBundles {
+		bundleFactory := client.NewBundleFactory()
+		bundleFactory.AddInput(conflictSeed.GetAddress(0), -400)
+		bundleFactory.AddOutput(conflictSeed.GetAddress(uint64(i+1)), 400, "Testmessage")
+
+		generatedBundles[i] = bundleFactory.GenerateBundle(tipselection.GetRandomTip(), tipselection.GetRandomTip())
+		for _, transaction := range generatedBundles[i].GetTransactions() {
+			tangle.StoreTransaction(transaction)
+		}
+	}
+
+	var wg sync.WaitGroup
+
+	solidClosure := events.NewClosure(func(bundle *bundle.Bundle, transactions []*value_transaction.ValueTransaction) {
+		wg.Done()
+	})
+	Events.BundleSolid.Attach(solidClosure)
+
+	var detectedConflicts int
+	var detectedConflictsMutex sync.Mutex
+	conflictClosure := events.NewClosure(func(bundle *bundle.Bundle, conflictingBundle *bundle.Bundle) {
+		if bundle.GetHash() == generatedBundles[1].GetTransactions()[0].GetHash() && conflictingBundle.GetHash() == generatedBundles[0].GetTransactions()[0].GetHash() {
+			detectedConflictsMutex.Lock()
+			detectedConflicts++
+			detectedConflictsMutex.Unlock()
+		}
+	})
+	Events.Conflict.Attach(conflictClosure)
+
+	for _, generatedBundle := range generatedBundles {
+		wg.Add(1)
+
+		if err := ProcessSolidBundleHead(generatedBundle.GetTransactions()[0]); err != nil {
+			t.Error(err)
+		}
+
+		wg.Wait()
+	}
+
+	assert.Equal(t, detectedConflicts, 1, "conflict not detected")
+	for _, generatedBundle := range generatedBundles {
+		for _, transaction := range generatedBundle.GetTransactions() {
+			conflicting, err := IsConflicting(transaction.GetHash())
+			if err != nil {
+				t.Error(err)
+			}
+			assert.Equal(t, conflicting, true, "transaction not marked as conflicting")
+		}
+	}
+
+	Events.BundleSolid.Detach(solidClosure)
+	Events.Conflict.Detach(conflictClosure)
+
+	// shutdown test node
+	node.Shutdown()
+}
+
+func TestProcessSolidValueBundle_InvalidSignature(t *testing.T) {
+	bundleFactory := client.NewBundleFactory()
+	bundleFactory.AddInput(seed.GetAddress(0), -400)
+	bundleFactory.AddOutput(seed.GetAddress(1), 400, "Testmessage")
+
+	generatedBundle := bundleFactory.GenerateBundle(tipselection.GetRandomTip(), tipselection.GetRandomTip())
+
+	// replace the signature of the input - the essence of the bundle stays the same
+	for _, transaction := range generatedBundle.GetTransactions() {
+		if transaction.GetValue() < 0 {
+			transaction.SetSignatureMessageFragment(trinary.Trytes(strings.Repeat("9", len(transaction.GetSignatureMessageFragment()))))
+		}
+	}
+
+	var invalidBundles int
+	invalidClosure := events.NewClosure(func(bundle *bundle.Bundle, transactions []*value_transaction.ValueTransaction) {
+		invalidBundles++
+	})
+	Events.InvalidBundle.Attach(invalidClosure)
+	defer Events.InvalidBundle.Detach(invalidClosure)
+
+	solidClosure := events.NewClosure(func(bundle *bundle.Bundle, transactions []*value_transaction.ValueTransaction) {
+		t.Error("bundle with invalid signatures was marked as solid")
+	})
+	Events.BundleSolid.Attach(solidClosure)
+	defer Events.BundleSolid.Detach(solidClosure)
+
+	invalidBundle := bundle.New(generatedBundle.GetTransactions()[0].GetHash())
+	invalidBundle.SetValueBundle(true)
+
+	err := ProcessSolidValueBundle(invalidBundle, generatedBundle.GetTransactions())
+	assert.Equal(t, ErrProcessBundleFailed.Equals(err), true, "invalid bundle not rejected")
+	assert.Equal(t, invalidBundles, 1, "invalid bundle event not triggered")
+}
+
+func randomTrytes(length int) trinary.Trytes {
+	const alphabet = "9ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	result := make([]byte, length)
+	for i := range result {
+		result[i] = alphabet[random.Intn(len(alphabet))]
+	}
+
+	return trinary.Trytes(result)
+}
@@ -0,0 +1,184 @@
+package bundleprocessor
+
+import (
+	"sync"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/goshimmer/packages/model/bundle"
+	"github.com/iotaledger/goshimmer/packages/model/transactionmetadata"
+	"github.com/iotaledger/goshimmer/packages/model/value_transaction"
+	"github.com/iotaledger/goshimmer/packages/typeutils"
+	"github.com/iotaledger/goshimmer/plugins/tangle"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/iota.go/trinary"
+)
+
+// region plugin module setup //////////////////////////////////////////////////////////////////////////////////////////
+
+func configureConflictDetector(plugin *node.Plugin) {
+	if db, err := database.Get("addressSpends"); err != nil {
+		panic(err)
+	} else {
+		addressSpendsDatabase = db
+	}
+
+	Events.Conflict.Attach(events.NewClosure(func(bundle *bundle.Bundle, conflictingBundle *bundle.Bundle) {
+		log.Warningf("Bundle %s conflicts with bundle %s", bundle.GetHash(), conflictingBundle.GetHash())
+	}))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region public api ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Registers the spends of a solid value bundle and checks if other bundles with a different essence spend from the same
+// input addresses. Both bundles of every detected conflict are marked as conflicting and a Conflict event is triggered.
+// Reattachments (bundles with the same essence) are not considered to be conflicts.
+func DetectConflicts(valueBundle *bundle.Bundle, bundleTransactions []*value_transaction.ValueTransaction) errors.IdentifiableError {
+	conflictDetectionMutex.Lock()
+	defer conflictDetectionMutex.Unlock()
+
+	conflictingBundles := make(map[trinary.Trytes]trinary.Trytes)
+	for _, inputAddress := range getInputAddresses(bundleTransactions) {
+		spends, err := getAddressSpends(inputAddress)
+		if err != nil {
+			return err
+		}
+
+		for headTransactionHash, bundleEssenceHash := range spends {
+			if headTransactionHash != valueBundle.GetHash() && bundleEssenceHash != valueBundle.GetBundleEssenceHash() {
+				conflictingBundles[headTransactionHash] = bundleEssenceHash
+			}
+		}
+
+		if err := storeAddressSpend(inputAddress, valueBundle); err != nil {
+			return err
+		}
+	}
+
+	if len(conflictingBundles) == 0 {
+		return nil
+	}
+
+	if err := markTransactionsConflicting(valueBundle.GetTransactionHashes()); err != nil {
+		return err
+	}
+
+	for headTransactionHash, bundleEssenceHash := range conflictingBundles {
+		cachedConflictingBundle, err := tangle.GetBundle(headTransactionHash)
+		if err != nil {
+			return ErrProcessBundleFailed.Derive(err, "failed to retrieve conflicting bundle")
+		}
+
+		var conflictingBundle *bundle.Bundle
+		if cachedConflictingBundle != nil {
+			conflictingBundle = cachedConflictingBundle.Unwrap()
+			cachedConflictingBundle.Release()
+
+			if err := markTransactionsConflicting(conflictingBundle.GetTransactionHashes()); err != nil {
+				return err
+			}
+		} else {
+			// the conflicting bundle was pruned already - the spend still identifies it
+			conflictingBundle = bundle.New(headTransactionHash)
+			conflictingBundle.SetValueBundle(true)
+			conflictingBundle.SetBundleEssenceHash(bundleEssenceHash)
+		}
+
+		Events.Conflict.Trigger(valueBundle, conflictingBundle)
+	}
+
+	return nil
+}
+
+// Returns true if the given transaction belongs to a bundle that conflicts with another bundle.
+func IsConflicting(transactionHash trinary.Trytes) (bool, errors.IdentifiableError) {
+	if cachedMetadata, err := tangle.GetTransactionMetadata(transactionHash); err != nil {
+		return false, err
+	} else if cachedMetadata == nil {
+		return false, nil
+	} else {
+		defer cachedMetadata.Release()
+
+		return cachedMetadata.Unwrap().GetConflicting(), nil
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region database /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// contains the spends per input address (key = address + head transaction hash, value = bundle essence hash)
+var addressSpendsDatabase database.Database
+
+var conflictDetectionMutex sync.Mutex
+
+func getAddressSpends(address trinary.Trytes) (result map[trinary.Trytes]trinary.Trytes, err errors.IdentifiableError) {
+	result = make(map[trinary.Trytes]trinary.Trytes)
+
+	if dbErr := addressSpendsDatabase.ForEachWithPrefix(typeutils.StringToBytes(address), func(key []byte, value []byte) {
+		result[trinary.Trytes(string(key[ADDRESS_SIZE:]))] = trinary.Trytes(string(value))
+	}); dbErr != nil {
+		err = ErrProcessBundleFailed.Derive(dbErr, "failed to retrieve the spends of the address")
+	}
+
+	return
+}
+
+func storeAddressSpend(address trinary.Trytes, valueBundle *bundle.Bundle) errors.IdentifiableError {
+	key := make([]byte, ADDRESS_SIZE+HASH_SIZE)
+	copy(key[:ADDRESS_SIZE], typeutils.StringToBytes(address))
+	copy(key[ADDRESS_SIZE:], typeutils.StringToBytes(valueBundle.GetHash()))
+
+	if err := addressSpendsDatabase.Set(key, []byte(valueBundle.GetBundleEssenceHash())); err != nil {
+		return ErrProcessBundleFailed.Derive(err, "failed to store the spend of the address")
+	}
+
+	return nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utility methods //////////////////////////////////////////////////////////////////////////////////////////////
+
+func getInputAddresses(bundleTransactions []*value_transaction.ValueTransaction) (result []trinary.Trytes) {
+	result = make([]trinary.Trytes, 0)
+
+	seenAddresses := make(map[trinary.Trytes]bool)
+	for _, transaction := range bundleTransactions {
+		if address := transaction.GetAddress(); transaction.GetValue() < 0 && !seenAddresses[address] {
+			seenAddresses[address] = true
+
+			result = append(result, address)
+		}
+	}
+
+	return
+}
+
+func markTransactionsConflicting(transactionHashes []trinary.Trytes) errors.IdentifiableError {
+	for _, transactionHash := range transactionHashes {
+		cachedMetadata, err := tangle.GetTransactionMetadata(transactionHash, transactionmetadata.New)
+		if err != nil {
+			return ErrProcessBundleFailed.Derive(err, "failed to retrieve transaction metadata")
+		}
+
+		cachedMetadata.Unwrap().SetConflicting(true)
+		cachedMetadata.Release()
+	}
+
+	return nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region constants and variables //////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	ADDRESS_SIZE = 81
+	HASH_SIZE    = 81
+)
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -11,12 +11,14 @@ var Events = pluginEvents{
 	Error:         events.NewEvent(errorCaller),
 	BundleSolid:   events.NewEvent(bundleEventCaller),
 	InvalidBundle: events.NewEvent(bundleEventCaller),
+	Conflict:      events.NewEvent(conflictEventCaller),
 }
 
 type pluginEvents struct {
 	Error         *events.Event
 	BundleSolid   *events.Event
 	InvalidBundle *events.Event
+	Conflict      *events.Event
 }
 
 func errorCaller(handler interface{}, params ...interface{}) {
@@ -26,3 +28,7 @@ func errorCaller(handler interface{}, params ...interface{}) {
 func bundleEventCaller(handler interface{}, params ...interface{}) {
 	handler.(func(*bundle.Bundle, []*value_transaction.ValueTransaction))(params[0].(*bundle.Bundle), params[1].([]*value_transaction.ValueTransaction))
 }
+
+func conflictEventCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*bundle.Bundle, *bundle.Bundle))(params[0].(*bundle.Bundle), params[1].(*bundle.Bundle))
+}
@@ -14,12 +14,18 @@ var PLUGIN = node.NewPlugin("Bundle Processor", node.Enabled, configure, run)
 var log = logger.NewLogger("Bundle Processor")
 
 func configure(plugin *node.Plugin) {
+	configureConflictDetector(plugin)
+
 	tangle.Events.TransactionSolid.Attach(events.NewClosure(func(tx *value_transaction.ValueTransaction) {
 		if tx.IsHead() {
 			workerPool.Submit(tx)
 		}
 	}))
 
+	// make the tangle wait for the bundle processing before it flushes its caches on shutdown
+	tangle.RegisterProcessingWorkerPool(workerPool)
+	tangle.RegisterProcessingWorkerPool(valueBundleProcessorWorkerPool)
+
 	Events.Error.Attach(events.NewClosure(func(err errors.IdentifiableError) {
 		log.Error(err.Error())
 	}))
@@ -21,6 +21,19 @@ var valueBundleProcessorWorkerPool = workerpool.New(func(task workerpool.Task) {
 func ProcessSolidValueBundle(bundle *bundle.Bundle, bundleTransactions []*value_transaction.ValueTransaction) errors.IdentifiableError {
 	bundle.SetBundleEssenceHash(CalculateBundleHash(bundleTransactions))
 
+	// drop bundles with invalid signatures before they can take part in any conflict
+	if valid, err := ValidateSignatures(bundle.GetBundleEssenceHash(), bundleTransactions); err != nil {
+		return ErrProcessBundleFailed.Derive(err, "failed to validate the signatures of bundle "+bundle.GetHash())
+	} else if !valid {
+		Events.InvalidBundle.Trigger(bundle, bundleTransactions)
+
+		return ErrProcessBundleFailed.Derive(errors.New("invalid signature"), "bundle "+bundle.GetHash()+" has invalid signatures")
+	}
+
+	if err := DetectConflicts(bundle, bundleTransactions); err != nil {
+		return err
+	}
+
 	Events.BundleSolid.Trigger(bundle, bundleTransactions)
 
 	return nil
@@ -0,0 +1,22 @@
+package consensus
+
+import (
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/iota.go/trinary"
+)
+
+var Events = pluginEvents{
+	TransactionLiked:     events.NewEvent(opinionCaller),
+	TransactionFinalized: events.NewEvent(opinionCaller),
+}
+
+type pluginEvents struct {
+	// is triggered whenever the opinion about a voted transaction changes (the parameter indicates if it is liked)
+	TransactionLiked *events.Event
+	// is triggered when the opinion about a voted transaction is final
+	TransactionFinalized *events.Event
+}
+
+func opinionCaller(handler interface{}, params ...interface{}) {
+	handler.(func(trinary.Trytes, bool))(params[0].(trinary.Trytes), params[1].(bool))
+}
@@ -0,0 +1,21 @@
+package consensus
+
+import (
+	"time"
+
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	CFG_ROUND_INTERVAL      = "consensus.roundInterval"
+	CFG_QUERY_SAMPLE_SIZE   = "consensus.querySampleSize"
+	CFG_FINALIZATION_ROUNDS = "consensus.finalizationRounds"
+	CFG_MAX_ROUNDS          = "consensus.maxRounds"
+)
+
+func init() {
+	flag.Duration(CFG_ROUND_INTERVAL, 2*time.Second, "duration of a voting round")
+	flag.Int(CFG_QUERY_SAMPLE_SIZE, 10, "amount of random known peers that are queried in every voting round")
+	flag.Int(CFG_FINALIZATION_ROUNDS, 5, "amount of consecutive rounds without opinion change after which a vote is finalized")
+	flag.Int(CFG_MAX_ROUNDS, 50, "maximum amount of rounds after which a vote is finalized with the current opinion")
+}
@@ -0,0 +1,83 @@
+package consensus
+
+import (
+	"github.com/iotaledger/goshimmer/packages/model/bundle"
+	"github.com/iotaledger/goshimmer/packages/timeutil"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/server/udp"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/types/opinion"
+	"github.com/iotaledger/goshimmer/plugins/bundleprocessor"
+	"github.com/iotaledger/goshimmer/plugins/tangle"
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/parameter"
+)
+
+// region plugin module setup //////////////////////////////////////////////////////////////////////////////////////////
+
+var PLUGIN = node.NewPlugin("Consensus", node.Enabled, configure, run)
+var log = logger.NewLogger("Consensus")
+
+func configure(plugin *node.Plugin) {
+	querySampleSize = parameter.NodeConfig.GetInt(CFG_QUERY_SAMPLE_SIZE)
+	finalizationRounds = parameter.NodeConfig.GetInt(CFG_FINALIZATION_ROUNDS)
+	maxRounds = parameter.NodeConfig.GetInt(CFG_MAX_ROUNDS)
+
+	// the bundle that was seen first is liked initially
+	bundleprocessor.Events.Conflict.Attach(events.NewClosure(func(newBundle *bundle.Bundle, conflictingBundle *bundle.Bundle) {
+		startVoting(conflictingBundle, true)
+		startVoting(newBundle, false)
+	}))
+
+	// make the tangle wait for the finalization before it closes the database on shutdown
+	tangle.RegisterProcessingWorkerPool(finalizationWorkerPool)
+
+	daemon.Events.Shutdown.Attach(events.NewClosure(func() {
+		log.Info("Stopping Vote Finalization ...")
+
+		finalizationWorkerPool.Stop()
+	}))
+
+	daemon.Events.Shutdown.Attach(events.NewClosure(func() {
+		log.Info("Stopping Query Processor ...")
+
+		queryWorkerPool.Stop()
+	}))
+
+	udp.Events.ReceiveQuery.Attach(events.NewClosure(processQuery))
+
+	udp.Events.ReceiveOpinion.Attach(events.NewClosure(func(receivedOpinion *opinion.Opinion) {
+		registerOpinion(receivedOpinion)
+	}))
+}
+
+func run(plugin *node.Plugin) {
+	log.Info("Starting Vote Finalization ...")
+
+	daemon.BackgroundWorker("Consensus Vote Finalization", func() {
+		log.Info("Starting Vote Finalization ... done")
+		finalizationWorkerPool.Run()
+		log.Info("Stopping Vote Finalization ... done")
+	})
+
+	log.Info("Starting Query Processor ...")
+
+	daemon.BackgroundWorker("Consensus Query Processor", func() {
+		log.Info("Starting Query Processor ... done")
+		queryWorkerPool.Run()
+		log.Info("Stopping Query Processor ... done")
+	})
+
+	log.Info("Starting Voting ...")
+
+	daemon.BackgroundWorker("Consensus Voting", func() {
+		log.Info("Starting Voting ... done")
+
+		timeutil.Ticker(processVotingRound, parameter.NodeConfig.GetDuration(CFG_ROUND_INTERVAL))
+
+		log.Info("Stopping Voting ... done")
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
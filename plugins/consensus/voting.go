@@ -0,0 +1,435 @@
+package consensus
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/datastructure"
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/goshimmer/packages/model/bundle"
+	"github.com/iotaledger/goshimmer/packages/model/transactionmetadata"
+	"github.com/iotaledger/goshimmer/packages/model/value_transaction"
+	"github.com/iotaledger/goshimmer/packages/workerpool"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/instances/knownpeers"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/instances/ownpeer"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/protocol/types"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/types/opinion"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/types/peer"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/types/query"
+	"github.com/iotaledger/goshimmer/plugins/ledger"
+	"github.com/iotaledger/goshimmer/plugins/tangle"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/iota.go/trinary"
+)
+
+// region public api ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Returns true if the node is currently voting on the bundle with the given head transaction.
+func IsVoting(headTransactionHash trinary.Trytes) (result bool) {
+	votesMutex.Lock()
+	_, result = votes[headTransactionHash]
+	votesMutex.Unlock()
+
+	return
+}
+
+func GetActiveVotesCount() (result int) {
+	votesMutex.Lock()
+	result = len(votes)
+	votesMutex.Unlock()
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region voting ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// finalizes the votes of one round after the other (the tangle drains the pool before it closes the database)
+var finalizationWorkerPool = workerpool.New(func(task workerpool.Task) {
+	finalizeVotes(task.Param(0).([]*vote))
+
+	task.Return(nil)
+}, workerpool.WorkerCount(1), workerpool.QueueSize(FINALIZATION_QUEUE_SIZE))
+
+// starts a vote on a conflicting bundle with the given initial opinion
+func startVoting(votedBundle *bundle.Bundle, liked bool) {
+	headTransactionHash := votedBundle.GetHash()
+
+	if cachedMetadata, err := tangle.GetTransactionMetadata(headTransactionHash); err != nil {
+		log.Errorf("Unable to start voting on bundle %s: %s", headTransactionHash, err.Error())
+
+		return
+	} else if cachedMetadata != nil {
+		finalized := cachedMetadata.Unwrap().GetFinalized()
+		cachedMetadata.Release()
+
+		if finalized {
+			return
+		}
+	}
+
+	votesMutex.Lock()
+	defer votesMutex.Unlock()
+
+	if _, exists := votes[headTransactionHash]; exists {
+		return
+	}
+
+	votes[headTransactionHash] = &vote{
+		bundle:        votedBundle,
+		liked:         liked,
+		queriedPeers:  make(map[string]bool),
+		receivedLikes: make(map[string]bool),
+	}
+
+	if err := updateTransactionMetadata(votedBundle, func(metadata *transactionmetadata.TransactionMetadata) {
+		metadata.SetLiked(liked)
+	}); err != nil {
+		log.Errorf("Unable to update the opinion about bundle %s: %s", headTransactionHash, err.Error())
+	}
+	triggerForBundle(Events.TransactionLiked, votedBundle, liked)
+}
+
+// evaluates the opinions of the last round for every vote and queries a new sample of peers
+func processVotingRound() {
+	votesMutex.Lock()
+	defer votesMutex.Unlock()
+
+	finalizedVotes := make([]*vote, 0)
+	defer func() {
+		if len(finalizedVotes) != 0 {
+			finalizationWorkerPool.Submit(finalizedVotes)
+		}
+	}()
+
+	for headTransactionHash, currentVote := range votes {
+		if currentVote.rounds != 0 {
+			updateOpinion(currentVote)
+		}
+
+		if currentVote.stableRounds >= finalizationRounds || currentVote.rounds >= maxRounds {
+			delete(votes, headTransactionHash)
+
+			finalizedVotes = append(finalizedVotes, currentVote)
+
+			continue
+		}
+
+		currentVote.rounds++
+		currentVote.queriedPeers = make(map[string]bool)
+		currentVote.receivedLikes = make(map[string]bool)
+
+		outgoingQuery := &query.Query{
+			Issuer:          ownpeer.INSTANCE,
+			TransactionHash: headTransactionHash,
+			IssuingTime:     time.Now(),
+		}
+		outgoingQuery.Sign()
+		marshaledQuery := outgoingQuery.Marshal()
+
+		for _, queriedPeer := range getRandomPeers(querySampleSize) {
+			currentVote.queriedPeers[queriedPeer.GetIdentity().StringIdentifier] = true
+
+			go func(queriedPeer *peer.Peer) {
+				if _, err := queriedPeer.Send(marshaledQuery, types.PROTOCOL_TYPE_UDP, false); err != nil {
+					log.Debugf("error when sending query to %s: %s", queriedPeer.String(), err.Error())
+				}
+			}(queriedPeer)
+		}
+	}
+}
+
+// adopts the opinion of the majority of the peers that answered (has to be called while holding the votesMutex)
+func updateOpinion(currentVote *vote) {
+	if len(currentVote.receivedLikes) == 0 {
+		return
+	}
+
+	likes := 0
+	for _, liked := range currentVote.receivedLikes {
+		if liked {
+			likes++
+		}
+	}
+
+	// a random threshold prevents an attacker from keeping the network split by answering strategically
+	threshold := LIKE_THRESHOLD_MIN + rand.Float64()*(LIKE_THRESHOLD_MAX-LIKE_THRESHOLD_MIN)
+	liked := float64(likes)/float64(len(currentVote.receivedLikes)) > threshold
+
+	if liked == currentVote.liked {
+		currentVote.stableRounds++
+
+		return
+	}
+
+	currentVote.liked = liked
+	currentVote.stableRounds = 0
+
+	if err := updateTransactionMetadata(currentVote.bundle, func(metadata *transactionmetadata.TransactionMetadata) {
+		metadata.SetLiked(liked)
+	}); err != nil {
+		log.Errorf("Unable to update the opinion about bundle %s: %s", currentVote.bundle.GetHash(), err.Error())
+	}
+	triggerForBundle(Events.TransactionLiked, currentVote.bundle, liked)
+}
+
+// finalizes the votes of a round - the disliked bundles are rolled back before the liked bundles are applied, so the
+// winner of a conflict can spend the funds that the loser released
+func finalizeVotes(finalizedVotes []*vote) {
+	finalizationMutex.Lock()
+	defer finalizationMutex.Unlock()
+
+	// the conflicting bundles of a liked bundle might be finalized in a later round
+	retriedBundles := pendingApplies
+	pendingApplies = make(map[trinary.Trytes]*bundle.Bundle)
+
+	sort.SliceStable(finalizedVotes, func(i, j int) bool {
+		return !finalizedVotes[i].liked && finalizedVotes[j].liked
+	})
+
+	for _, finalizedVote := range finalizedVotes {
+		finalizeVote(finalizedVote.bundle, finalizedVote.liked)
+	}
+
+	for _, retriedBundle := range retriedBundles {
+		applyFinalizedBundle(retriedBundle)
+	}
+}
+
+// marks the transactions of the bundle as finalized and applies the outcome to the ledger state (has to be called while
+// holding the finalizationMutex)
+func finalizeVote(votedBundle *bundle.Bundle, liked bool) {
+	if err := updateTransactionMetadata(votedBundle, func(metadata *transactionmetadata.TransactionMetadata) {
+		metadata.SetFinalized(true)
+	}); err != nil {
+		log.Errorf("Unable to finalize bundle %s: %s", votedBundle.GetHash(), err.Error())
+
+		return
+	}
+
+	log.Infof("Finalized vote on bundle %s (liked: %t)", votedBundle.GetHash(), liked)
+
+	triggerForBundle(Events.TransactionFinalized, votedBundle, liked)
+
+	if !liked {
+		if err := ledger.RollbackBranch(votedBundle); err != nil {
+			log.Errorf("Unable to roll back bundle %s: %s", votedBundle.GetHash(), err.Error())
+		}
+
+		return
+	}
+
+	applyFinalizedBundle(votedBundle)
+}
+
+// applies a liked bundle to the ledger state - bundles that overspend are retried after the next finalization, since
+// the bundle that holds their funds might not be rolled back, yet (has to be called while holding the finalizationMutex)
+func applyFinalizedBundle(likedBundle *bundle.Bundle) {
+	if applied, err := ledger.IsBundleApplied(likedBundle.GetHash()); err != nil {
+		log.Errorf("Unable to check if bundle %s was applied: %s", likedBundle.GetHash(), err.Error())
+
+		return
+	} else if applied {
+		return
+	}
+
+	bundleTransactions := make([]*value_transaction.ValueTransaction, 0)
+	for _, transactionHash := range likedBundle.GetTransactionHashes() {
+		if cachedTransaction, err := tangle.GetTransaction(transactionHash); err != nil {
+			log.Errorf("Unable to load transaction %s: %s", transactionHash, err.Error())
+
+			return
+		} else if cachedTransaction == nil {
+			return
+		} else {
+			bundleTransactions = append(bundleTransactions, cachedTransaction.Unwrap())
+			cachedTransaction.Release()
+		}
+	}
+
+	if err := ledger.ApplyBundle(likedBundle, bundleTransactions); err != nil {
+		if ledger.ErrOverspend.Equals(err) {
+			log.Infof("Postponing bundle %s until its funds are released: %s", likedBundle.GetHash(), err.Error())
+
+			pendingApplies[likedBundle.GetHash()] = likedBundle
+
+			return
+		}
+
+		log.Warningf("Unable to apply bundle %s: %s", likedBundle.GetHash(), err.Error())
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region queries //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// answers the received queries with a limited amount of workers
+var queryWorkerPool = workerpool.New(func(task workerpool.Task) {
+	answerQuery(task.Param(0).(*query.Query))
+
+	task.Return(nil)
+}, workerpool.WorkerCount(QUERY_WORKER_COUNT), workerpool.QueueSize(QUERY_QUEUE_SIZE))
+
+// queues the query for an answer unless it was replayed (queries are dropped if the workers can not keep up)
+func processQuery(receivedQuery *query.Query) {
+	if isReplayedQuery(receivedQuery) {
+		log.Debugf("dropped replayed query for %s from %s", receivedQuery.TransactionHash, receivedQuery.Issuer.String())
+
+		return
+	}
+
+	if _, added := queryWorkerPool.TrySubmit(receivedQuery); !added {
+		log.Debugf("dropped query for %s from %s: too many pending queries", receivedQuery.TransactionHash, receivedQuery.Issuer.String())
+	}
+}
+
+// checks if the issuer sent a query for the same transaction with the same or a later issuing time before (the issuing
+// time of the query was already checked to be recent when it was unmarshaled)
+func isReplayedQuery(receivedQuery *query.Query) bool {
+	key := receivedQuery.Issuer.GetIdentity().StringIdentifier + string(receivedQuery.TransactionHash)
+
+	lastQueryTimesMutex.Lock()
+	defer lastQueryTimesMutex.Unlock()
+
+	if lastQueryTime := lastQueryTimes.Get(key); lastQueryTime != nil && !receivedQuery.IssuingTime.After(lastQueryTime.(time.Time)) {
+		return true
+	}
+	lastQueryTimes.Set(key, receivedQuery.IssuingTime)
+
+	return false
+}
+
+func answerQuery(receivedQuery *query.Query) {
+	outgoingOpinion := &opinion.Opinion{
+		Issuer:          ownpeer.INSTANCE,
+		TransactionHash: receivedQuery.TransactionHash,
+		Value:           getOwnOpinion(receivedQuery.TransactionHash),
+	}
+	outgoingOpinion.Sign()
+
+	if _, err := receivedQuery.Issuer.Send(outgoingOpinion.Marshal(), types.PROTOCOL_TYPE_UDP, false); err != nil {
+		log.Debugf("error when sending opinion to %s: %s", receivedQuery.Issuer.String(), err.Error())
+	}
+}
+
+func registerOpinion(receivedOpinion *opinion.Opinion) {
+	if receivedOpinion.Value == opinion.VALUE_UNKNOWN {
+		return
+	}
+
+	votesMutex.Lock()
+	defer votesMutex.Unlock()
+
+	// only count the answers of the peers that were queried in the current round
+	peerIdentifier := receivedOpinion.Issuer.GetIdentity().StringIdentifier
+	if currentVote, exists := votes[receivedOpinion.TransactionHash]; exists && currentVote.queriedPeers[peerIdentifier] {
+		currentVote.receivedLikes[peerIdentifier] = receivedOpinion.Value == opinion.VALUE_LIKE
+	}
+}
+
+// transactions that are not conflicting are always liked
+func getOwnOpinion(transactionHash trinary.Trytes) opinion.Value {
+	cachedMetadata, err := tangle.GetTransactionMetadata(transactionHash)
+	if err != nil || cachedMetadata == nil {
+		return opinion.VALUE_UNKNOWN
+	}
+	defer cachedMetadata.Release()
+
+	if metadata := cachedMetadata.Unwrap(); !metadata.GetConflicting() || metadata.GetLiked() {
+		return opinion.VALUE_LIKE
+	}
+
+	return opinion.VALUE_DISLIKE
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utility methods //////////////////////////////////////////////////////////////////////////////////////////////
+
+func getRandomPeers(count int) []*peer.Peer {
+	knownPeers := knownpeers.INSTANCE.List()
+
+	rand.Shuffle(len(knownPeers), func(i, j int) {
+		knownPeers[i], knownPeers[j] = knownPeers[j], knownPeers[i]
+	})
+
+	if len(knownPeers) > count {
+		knownPeers = knownPeers[:count]
+	}
+
+	return knownPeers
+}
+
+func updateTransactionMetadata(votedBundle *bundle.Bundle, update func(metadata *transactionmetadata.TransactionMetadata)) errors.IdentifiableError {
+	for _, transactionHash := range votedBundle.GetTransactionHashes() {
+		cachedMetadata, err := tangle.GetTransactionMetadata(transactionHash, transactionmetadata.New)
+		if err != nil {
+			return err
+		}
+
+		update(cachedMetadata.Unwrap())
+		cachedMetadata.Release()
+	}
+
+	return nil
+}
+
+func triggerForBundle(event *events.Event, votedBundle *bundle.Bundle, liked bool) {
+	for _, transactionHash := range votedBundle.GetTransactionHashes() {
+		event.Trigger(transactionHash, liked)
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region types and interfaces /////////////////////////////////////////////////////////////////////////////////////////
+
+type vote struct {
+	bundle        *bundle.Bundle
+	liked         bool
+	rounds        int
+	stableRounds  int
+	queriedPeers  map[string]bool
+	receivedLikes map[string]bool
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region constants and variables //////////////////////////////////////////////////////////////////////////////////////
+
+var votes = make(map[trinary.Trytes]*vote)
+
+var votesMutex sync.Mutex
+
+// contains the liked bundles that could not be applied, yet
+var pendingApplies = make(map[trinary.Trytes]*bundle.Bundle)
+
+var finalizationMutex sync.Mutex
+
+// contains the issuing time of the last query per issuer and transaction
+var lastQueryTimes = datastructure.NewLRUCache(QUERY_REPLAY_CACHE_SIZE)
+
+var lastQueryTimesMutex sync.Mutex
+
+var querySampleSize int
+
+var finalizationRounds int
+
+var maxRounds int
+
+const (
+	LIKE_THRESHOLD_MIN = 0.4
+	LIKE_THRESHOLD_MAX = 0.6
+
+	FINALIZATION_QUEUE_SIZE = 100
+
+	QUERY_WORKER_COUNT      = 10
+	QUERY_QUEUE_SIZE        = 100
+	QUERY_REPLAY_CACHE_SIZE = 10000
+)
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
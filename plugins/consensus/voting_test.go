@@ -0,0 +1,156 @@
+package consensus
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/client"
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/identity"
+	"github.com/iotaledger/goshimmer/packages/model/bundle"
+	"github.com/iotaledger/goshimmer/packages/model/meta_transaction"
+	"github.com/iotaledger/goshimmer/packages/model/value_transaction"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/types/peer"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/types/query"
+	"github.com/iotaledger/goshimmer/plugins/ledger"
+	"github.com/iotaledger/goshimmer/plugins/tangle"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/parameter"
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/trinary"
+	"github.com/magiconair/properties/assert"
+)
+
+var seed = client.NewSeed("YFHQWAUPCXC9S9DSHP9NDF9RLNPMZVCMSJKUKQP9SWUSUCPRQXCMDVDVZ9SHHESHIQNCXWBJF9UJSWE9Z", consts.SecurityLevelMedium)
+
+func TestMain(m *testing.M) {
+	parameter.FetchConfig(false)
+	parameter.NodeConfig.Set(database.CFG_BACKEND, database.BACKEND_MEMORY)
+	os.Exit(m.Run())
+}
+
+func TestFinalizeVotes(t *testing.T) {
+	// start a test node
+	node.Start(tangle.PLUGIN)
+
+	if err := ledger.SetBalances(map[trinary.Trytes]int64{seed.GetAddress(0).GetTrytes(): 400}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the applied bundle loses the conflict - it is rolled back before the winner is applied
+	firstSpend, secondSpend, thirdSpend := storeTransfer(t, 1), storeTransfer(t, 2), storeTransfer(t, 3)
+	if err := ledger.ApplyBundle(firstSpend, loadTransactions(t, firstSpend)); err != nil {
+		t.Fatal(err)
+	}
+
+	finalizeVotes([]*vote{{bundle: secondSpend, liked: true}, {bundle: firstSpend, liked: false}})
+
+	assert.Equal(t, isBundleApplied(t, firstSpend), false, "the disliked bundle was not rolled back")
+	assert.Equal(t, isBundleApplied(t, secondSpend), true, "the liked bundle was not applied")
+	assert.Equal(t, isFinalized(t, firstSpend), true)
+	assert.Equal(t, isFinalized(t, secondSpend), true)
+	assertBalance(t, seed.GetAddress(1).GetTrytes(), 0)
+	assertBalance(t, seed.GetAddress(2).GetTrytes(), 400)
+
+	// the winner of a conflict that is finalized before the loser is applied once the funds are released
+	finalizeVotes([]*vote{{bundle: thirdSpend, liked: true}})
+	assert.Equal(t, isBundleApplied(t, thirdSpend), false, "the overspending bundle was applied")
+
+	finalizeVotes([]*vote{{bundle: secondSpend, liked: false}})
+	assert.Equal(t, isBundleApplied(t, secondSpend), false, "the disliked bundle was not rolled back")
+	assert.Equal(t, isBundleApplied(t, thirdSpend), true, "the postponed bundle was not applied")
+	assertBalance(t, seed.GetAddress(2).GetTrytes(), 0)
+	assertBalance(t, seed.GetAddress(3).GetTrytes(), 400)
+
+	// shutdown test node
+	node.Shutdown()
+}
+
+// stores a bundle that transfers the funds of the first address of the seed to the address with the given index
+func TestIsReplayedQuery(t *testing.T) {
+	issuer := &peer.Peer{}
+	issuer.SetIdentity(identity.GenerateRandomIdentity())
+
+	issuingTime := time.Now()
+	receivedQuery := &query.Query{
+		Issuer:          issuer,
+		TransactionHash: "A99999999999999999999999999999999999999999999999999999999999999999999999999999999",
+		IssuingTime:     issuingTime,
+	}
+	assert.Equal(t, isReplayedQuery(receivedQuery), false)
+	assert.Equal(t, isReplayedQuery(receivedQuery), true)
+
+	// older queries are rejected as well and only later queries are answered again
+	receivedQuery.IssuingTime = issuingTime.Add(-time.Second)
+	assert.Equal(t, isReplayedQuery(receivedQuery), true)
+
+	receivedQuery.IssuingTime = issuingTime.Add(time.Second)
+	assert.Equal(t, isReplayedQuery(receivedQuery), false)
+}
+
+func storeTransfer(t *testing.T, outputIndex uint64) *bundle.Bundle {
+	bundleFactory := client.NewBundleFactory()
+	bundleFactory.AddInput(seed.GetAddress(0), -400)
+	bundleFactory.AddOutput(seed.GetAddress(outputIndex), 400, "Testmessage")
+
+	generatedBundle := bundleFactory.GenerateBundle(meta_transaction.BRANCH_NULL_HASH, meta_transaction.BRANCH_NULL_HASH)
+	transactions := generatedBundle.GetTransactions()
+
+	transactionHashes := make([]trinary.Trytes, len(transactions))
+	for i, transaction := range transactions {
+		tangle.StoreTransaction(transaction)
+
+		transactionHashes[i] = transaction.GetHash()
+	}
+
+	valueBundle := bundle.New(transactionHashes[0])
+	valueBundle.SetValueBundle(true)
+	valueBundle.SetBundleEssenceHash(generatedBundle.GetEssenceHash())
+	valueBundle.SetTransactionHashes(transactionHashes)
+
+	return valueBundle
+}
+
+func loadTransactions(t *testing.T, valueBundle *bundle.Bundle) []*value_transaction.ValueTransaction {
+	result := make([]*value_transaction.ValueTransaction, 0)
+	for _, transactionHash := range valueBundle.GetTransactionHashes() {
+		cachedTransaction, err := tangle.GetTransaction(transactionHash)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result = append(result, cachedTransaction.Unwrap())
+		cachedTransaction.Release()
+	}
+
+	return result
+}
+
+func isBundleApplied(t *testing.T, valueBundle *bundle.Bundle) bool {
+	applied, err := ledger.IsBundleApplied(valueBundle.GetHash())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return applied
+}
+
+func isFinalized(t *testing.T, valueBundle *bundle.Bundle) bool {
+	cachedMetadata, err := tangle.GetTransactionMetadata(valueBundle.GetHash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cachedMetadata.Release()
+
+	return cachedMetadata.Unwrap().GetFinalized()
+}
+
+func assertBalance(t *testing.T, address trinary.Trytes, expectedBalance int64) {
+	balance, err := ledger.GetBalance(address)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, balance, expectedBalance, "wrong balance of "+address)
+}
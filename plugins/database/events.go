@@ -0,0 +1,26 @@
+package database
+
+import (
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/hive.go/events"
+)
+
+var Events = pluginEvents{
+	StatsUpdated:     events.NewEvent(statsCaller),
+	GarbageCollected: events.NewEvent(intCaller),
+}
+
+type pluginEvents struct {
+	// is triggered whenever the database statistics were updated
+	StatsUpdated *events.Event
+	// is triggered after the value log garbage collection ran (with the amount of rewritten files)
+	GarbageCollected *events.Event
+}
+
+func statsCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*database.Stats))(params[0].(*database.Stats))
+}
+
+func intCaller(handler interface{}, params ...interface{}) {
+	handler.(func(int))(params[0].(int))
+}
@@ -0,0 +1,25 @@
+package database
+
+import (
+	"time"
+
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	CFG_MAINTENANCE_INTERVAL = "database.maintenanceInterval"
+	CFG_KEY_COUNT_INTERVAL   = "database.keyCountInterval"
+	CFG_GC_INTERVAL          = "database.gcInterval"
+	CFG_GC_DISCARD_RATIO     = "database.gcDiscardRatio"
+	CFG_RESTORE              = "database.restore"
+	CFG_RESTORE_IDENTITY     = "database.restoreIdentity"
+)
+
+func init() {
+	flag.Duration(CFG_MAINTENANCE_INTERVAL, 1*time.Minute, "interval in which the database statistics are updated and the database is checked for idleness")
+	flag.Duration(CFG_KEY_COUNT_INTERVAL, 1*time.Hour, "interval in which the keys of the database are counted (this requires a full scan of the database, 0 disables the counting)")
+	flag.Duration(CFG_GC_INTERVAL, 30*time.Minute, "interval in which the value log garbage collection runs (it also runs whenever the database is idle)")
+	flag.Float64(CFG_GC_DISCARD_RATIO, 0.5, "ratio of stale data that a value log file needs to contain to be rewritten")
+	flag.String(CFG_RESTORE, "", "path of a backup that is restored into the (empty) database before the node starts")
+	flag.Bool(CFG_RESTORE_IDENTITY, false, "restore backups that contain the identity of the node that created them (this node takes over the identity, so the other node must not run anymore)")
+}
@@ -0,0 +1,127 @@
+package database
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/timeutil"
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/parameter"
+)
+
+// region plugin module setup //////////////////////////////////////////////////////////////////////////////////////////
+
+// PLUGIN has to run before the schema plugin, so a restored backup gets migrated to the current schema.
+var PLUGIN = node.NewPlugin("Database", node.Enabled, configure, run)
+var log = logger.NewLogger("Database")
+
+func configure(plugin *node.Plugin) {
+	if backupPath := parameter.NodeConfig.GetString(CFG_RESTORE); backupPath != "" {
+		if err := restoreBackup(backupPath); err != nil {
+			panic(err)
+		}
+	}
+
+	lastGarbageCollection = time.Now()
+	lastGarbageCollectionWriteCount = database.GetWriteCount()
+	lastMaintenanceWriteCount = lastGarbageCollectionWriteCount
+}
+
+func run(plugin *node.Plugin) {
+	log.Info("Starting Database Maintenance ...")
+
+	daemon.BackgroundWorker("Database Maintenance", func() {
+		log.Info("Starting Database Maintenance ... done")
+
+		timeutil.Ticker(runMaintenance, parameter.NodeConfig.GetDuration(CFG_MAINTENANCE_INTERVAL))
+
+		log.Info("Stopping Database Maintenance ... done")
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region public api ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Returns the statistics of the last maintenance run (nil if there was none yet).
+func GetStats() *database.Stats {
+	statsMutex.RLock()
+	defer statsMutex.RUnlock()
+
+	return stats
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utility methods //////////////////////////////////////////////////////////////////////////////////////////////
+
+func runMaintenance() {
+	// counting the keys requires a full scan of the database, so the counts are updated less often than the sizes
+	if keyCountInterval := parameter.NodeConfig.GetDuration(CFG_KEY_COUNT_INTERVAL); keyCountInterval != 0 && time.Since(lastKeyCount) >= keyCountInterval {
+		lastKeyCount = time.Now()
+
+		if currentKeyCounts, err := database.CountKeys(); err != nil {
+			if err != database.ErrDatabaseClosed {
+				log.Errorf("Unable to count the keys of the database: %s", err.Error())
+			}
+		} else {
+			keyCounts = currentKeyCounts
+		}
+	}
+
+	if currentStats, err := database.GetStats(); err != nil {
+		log.Errorf("Unable to retrieve the database statistics: %s", err.Error())
+	} else {
+		currentStats.KeyCounts = keyCounts
+
+		statsMutex.Lock()
+		stats = currentStats
+		statsMutex.Unlock()
+
+		Events.StatsUpdated.Trigger(currentStats)
+	}
+
+	// collect the garbage on schedule or as soon as the database is idle (but only if it was written to since the last run)
+	writeCount := database.GetWriteCount()
+	idle := writeCount == lastMaintenanceWriteCount && writeCount != lastGarbageCollectionWriteCount
+	lastMaintenanceWriteCount = writeCount
+	if !idle && time.Since(lastGarbageCollection) < parameter.NodeConfig.GetDuration(CFG_GC_INTERVAL) {
+		return
+	}
+
+	lastGarbageCollection = time.Now()
+	lastGarbageCollectionWriteCount = writeCount
+
+	if rewrittenFiles, err := database.RunGarbageCollection(parameter.NodeConfig.GetFloat64(CFG_GC_DISCARD_RATIO)); err != nil {
+		log.Errorf("Unable to collect the garbage of the value log: %s", err.Error())
+	} else {
+		log.Debugf("Collected the garbage of the value log (%d files rewritten) in %v", rewrittenFiles, time.Since(lastGarbageCollection))
+
+		Events.GarbageCollected.Trigger(rewrittenFiles)
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region constants and variables //////////////////////////////////////////////////////////////////////////////////////
+
+var stats *database.Stats
+
+var statsMutex sync.RWMutex
+
+// the following variables are only accessed by the maintenance worker
+
+var lastGarbageCollection time.Time
+
+var lastGarbageCollectionWriteCount uint64
+
+var lastMaintenanceWriteCount uint64
+
+var lastKeyCount time.Time
+
+var keyCounts map[string]uint64
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
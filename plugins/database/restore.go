@@ -0,0 +1,38 @@
+package database
+
+import (
+	"bufio"
+	"os"
+
+	"github.com/iotaledger/goshimmer/packages/backup"
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/hive.go/parameter"
+)
+
+// loads the backup at the given path into the empty database
+func restoreBackup(backupPath string) errors.IdentifiableError {
+	log.Infof("Restoring backup %s ...", backupPath)
+
+	backupFile, err := os.Open(backupPath)
+	if err != nil {
+		return backup.ErrIOError.Derive(err, "failed to open the backup")
+	}
+	defer backupFile.Close()
+
+	header, restoreErr := backup.Restore(bufio.NewReader(backupFile), parameter.NodeConfig.GetBool(CFG_RESTORE_IDENTITY))
+	if restoreErr != nil {
+		if backup.ErrIdentityIncluded.Equals(restoreErr) {
+			log.Errorf("The backup contains the identity of another node - set %s to take it over", CFG_RESTORE_IDENTITY)
+		}
+
+		return restoreErr
+	}
+
+	if header.IdentityIncluded {
+		log.Warningf("The node takes over the identity %s of the backup", header.Identity.StringIdentifier)
+	}
+
+	log.Infof("Restoring backup %s ... done (node %s, schema version %d, created at %s)", backupPath, header.Identity.StringIdentifier, header.SchemaVersion, header.CreationTime.Format("2006-01-02 15:04:05"))
+
+	return nil
+}
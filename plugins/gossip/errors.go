@@ -9,4 +9,6 @@ var (
 	ErrInvalidStateTransition       = errors.New("protocol error: invalid state transition message")
 	ErrSendFailed                   = errors.Wrap(errors.New("protocol error"), "failed to send message")
 	ErrInvalidSendParam             = errors.New("invalid parameter passed to send")
+	ErrInvalidFrame                 = errors.New("protocol error: invalid frame")
+	ErrInvalidNeighborDefinition    = errors.New("invalid neighbor definition")
 )
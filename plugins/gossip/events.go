@@ -1,11 +1,14 @@
 package gossip
 
 import (
+	"time"
+
 	"github.com/iotaledger/goshimmer/packages/errors"
 	"github.com/iotaledger/goshimmer/packages/identity"
 	"github.com/iotaledger/goshimmer/packages/model/meta_transaction"
 	"github.com/iotaledger/goshimmer/packages/network"
 	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/iota.go/trinary"
 )
 
 var Events = pluginEvents{
@@ -20,9 +23,18 @@ var Events = pluginEvents{
 	// high level protocol events
 	DropNeighbor:              events.NewEvent(neighborCaller),
 	SendTransaction:           events.NewEvent(transactionCaller),
-	SendTransactionRequest:    events.NewEvent(transactionCaller), // TODO
+	SendTransactionRequest:    events.NewEvent(transactionHashCaller),
 	ReceiveTransaction:        events.NewEvent(transactionCaller),
-	ReceiveTransactionRequest: events.NewEvent(transactionCaller), // TODO
+	NewTransaction:            events.NewEvent(neighborTransactionCaller),
+	DuplicateTransaction:      events.NewEvent(optionalNeighborCaller),
+	InvalidTransaction:        events.NewEvent(neighborTransactionCaller),
+	SentTransaction:           events.NewEvent(neighborTransactionCaller),
+	DroppedTransaction:        events.NewEvent(neighborTransactionCaller),
+	DroppedTransactionRequest: events.NewEvent(transactionRequestCaller),
+	ReceiveTransactionRequest: events.NewEvent(transactionRequestCaller),
+	TransactionRequestFailed:  events.NewEvent(transactionHashCaller),
+	ReceiveHeartbeat:          events.NewEvent(neighborDurationCaller),
+	HeartbeatTimeout:          events.NewEvent(neighborCaller),
 	ProtocolError:             events.NewEvent(transactionCaller), // TODO
 
 	// generic events
@@ -43,7 +55,16 @@ type pluginEvents struct {
 	SendTransaction           *events.Event
 	SendTransactionRequest    *events.Event
 	ReceiveTransaction        *events.Event
+	NewTransaction            *events.Event
+	DuplicateTransaction      *events.Event
+	InvalidTransaction        *events.Event
+	SentTransaction           *events.Event
+	DroppedTransaction        *events.Event
+	DroppedTransactionRequest *events.Event
 	ReceiveTransactionRequest *events.Event
+	TransactionRequestFailed  *events.Event
+	ReceiveHeartbeat          *events.Event
+	HeartbeatTimeout          *events.Event
 	ProtocolError             *events.Event
 
 	// generic events
@@ -58,6 +79,7 @@ type protocolEvents struct {
 	ReceiveDropConnection     *events.Event
 	ReceiveTransactionData    *events.Event
 	ReceiveRequestData        *events.Event
+	ReceiveHeartbeatData      *events.Event
 	HandshakeCompleted        *events.Event
 	Error                     *events.Event
 }
@@ -84,6 +106,15 @@ func neighborCaller(handler interface{}, params ...interface{}) {
 	handler.(func(*Neighbor))(params[0].(*Neighbor))
 }
 
+func optionalNeighborCaller(handler interface{}, params ...interface{}) {
+	var neighbor *Neighbor
+	if params[0] != nil {
+		neighbor = params[0].(*Neighbor)
+	}
+
+	handler.(func(*Neighbor))(neighbor)
+}
+
 func errorCaller(handler interface{}, params ...interface{}) {
 	handler.(func(errors.IdentifiableError))(params[0].(errors.IdentifiableError))
 }
@@ -95,3 +126,24 @@ func dataCaller(handler interface{}, params ...interface{}) {
 func transactionCaller(handler interface{}, params ...interface{}) {
 	handler.(func(*meta_transaction.MetaTransaction))(params[0].(*meta_transaction.MetaTransaction))
 }
+
+func neighborTransactionCaller(handler interface{}, params ...interface{}) {
+	var neighbor *Neighbor
+	if params[0] != nil {
+		neighbor = params[0].(*Neighbor)
+	}
+
+	handler.(func(*Neighbor, *meta_transaction.MetaTransaction))(neighbor, params[1].(*meta_transaction.MetaTransaction))
+}
+
+func neighborDurationCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*Neighbor, time.Duration))(params[0].(*Neighbor), params[1].(time.Duration))
+}
+
+func transactionHashCaller(handler interface{}, params ...interface{}) {
+	handler.(func(trinary.Trytes))(params[0].(trinary.Trytes))
+}
+
+func transactionRequestCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*Neighbor, trinary.Trytes))(params[0].(*Neighbor), params[1].(trinary.Trytes))
+}
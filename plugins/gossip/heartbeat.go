@@ -0,0 +1,180 @@
+package gossip
+
+import (
+	"encoding/binary"
+	"sync/atomic"
+	"time"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/parameter"
+)
+
+// region plugin module setup //////////////////////////////////////////////////////////////////////////////////////////
+
+func configureHeartbeats(plugin *node.Plugin) {
+	heartbeatInterval = parameter.NodeConfig.GetDuration(GOSSIP_HEARTBEAT_INTERVAL)
+	heartbeatMaxMissed = parameter.NodeConfig.GetInt(GOSSIP_HEARTBEAT_MAX_MISSED)
+
+	for _, neighbor := range neighbors.GetMap() {
+		setupHeartbeatHandlers(neighbor)
+	}
+
+	Events.AddNeighbor.Attach(events.NewClosure(setupHeartbeatHandlers))
+
+	Events.HeartbeatTimeout.Attach(events.NewClosure(func(neighbor *Neighbor) {
+		log.Warningf("dropping connection to %s after %d missed heartbeats", neighborIdentifier(neighbor), heartbeatMaxMissed)
+	}))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utility methods //////////////////////////////////////////////////////////////////////////////////////////////
+
+func setupHeartbeatHandlers(neighbor *Neighbor) {
+	neighbor.Events.ProtocolConnectionEstablished.Attach(events.NewClosure(func(protocol *protocol) {
+		// version 1 of the protocol can not transport heartbeats
+		if protocol.Version >= VERSION_2 && heartbeatInterval > 0 {
+			startHeartbeat(neighbor, protocol)
+		}
+	}))
+}
+
+// sends heartbeats in the configured interval and drops the connection if the neighbor stays silent for too long
+func startHeartbeat(neighbor *Neighbor, protocol *protocol) {
+	disconnectSignal := make(chan int)
+	protocol.Conn.Events.Close.Attach(events.NewClosure(func() {
+		close(disconnectSignal)
+	}))
+
+	daemon.BackgroundWorker("Gossip Heartbeat ("+neighbor.GetIdentity().StringIdentifier+")", func() {
+		ticker := time.NewTicker(heartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-daemon.ShutdownSignal:
+				return
+
+			case <-disconnectSignal:
+				return
+
+			case <-ticker.C:
+				if heartbeatMaxMissed > 0 && time.Since(protocol.getLastReceived()) > time.Duration(heartbeatMaxMissed)*heartbeatInterval {
+					Events.HeartbeatTimeout.Trigger(neighbor)
+
+					_ = protocol.Conn.Close()
+
+					return
+				}
+
+				_ = protocol.Send(newHeartbeatFrameV2(HEARTBEAT_REQUEST, time.Now()))
+			}
+		}
+	})
+}
+
+// answers heartbeat requests and measures the round trip time of the responses
+func receiveHeartbeatV2(protocol *protocol, data []byte) {
+	if len(data) != MARSHALED_HEARTBEAT_TOTAL_SIZE {
+		return
+	}
+
+	switch data[MARSHALED_HEARTBEAT_TYPE_START] {
+	case HEARTBEAT_REQUEST:
+		response := make([]byte, MARSHALED_HEARTBEAT_TOTAL_SIZE)
+		copy(response, data)
+		response[MARSHALED_HEARTBEAT_TYPE_START] = HEARTBEAT_RESPONSE
+
+		go protocol.Send(newFrameV2(MESSAGE_TYPE_HEARTBEAT, response))
+
+	case HEARTBEAT_RESPONSE:
+		sentTime := time.Unix(0, int64(binary.BigEndian.Uint64(data[MARSHALED_HEARTBEAT_TIME_START:MARSHALED_HEARTBEAT_TIME_END])))
+		roundTripTime := time.Since(sentTime)
+		if roundTripTime < 0 {
+			return
+		}
+
+		if neighbor := protocol.Neighbor; neighbor != nil {
+			neighbor.setRoundTripTime(roundTripTime)
+
+			Events.ReceiveHeartbeat.Trigger(neighbor, roundTripTime)
+		}
+	}
+}
+
+func newHeartbeatFrameV2(heartbeatType byte, sentTime time.Time) *frameV2 {
+	payload := make([]byte, MARSHALED_HEARTBEAT_TOTAL_SIZE)
+	payload[MARSHALED_HEARTBEAT_TYPE_START] = heartbeatType
+	binary.BigEndian.PutUint64(payload[MARSHALED_HEARTBEAT_TIME_START:MARSHALED_HEARTBEAT_TIME_END], uint64(sentTime.UnixNano()))
+
+	return newFrameV2(MESSAGE_TYPE_HEARTBEAT, payload)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region liveness tracking ////////////////////////////////////////////////////////////////////////////////////////////
+
+// Returns the time when we last received data from the neighbor (the zero time if we never did).
+func (neighbor *Neighbor) GetLastSeen() time.Time {
+	if lastSeen := atomic.LoadInt64(&neighbor.lastSeen); lastSeen != 0 {
+		return time.Unix(0, lastSeen)
+	}
+
+	return time.Time{}
+}
+
+// Returns the round trip time of the last heartbeat (0 if it was not measured yet).
+func (neighbor *Neighbor) GetRoundTripTime() time.Duration {
+	return time.Duration(atomic.LoadInt64(&neighbor.roundTripTime))
+}
+
+func (neighbor *Neighbor) setLastSeen(lastSeen time.Time) {
+	atomic.StoreInt64(&neighbor.lastSeen, lastSeen.UnixNano())
+}
+
+func (neighbor *Neighbor) setRoundTripTime(roundTripTime time.Duration) {
+	atomic.StoreInt64(&neighbor.roundTripTime, int64(roundTripTime))
+}
+
+func (protocol *protocol) getLastReceived() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&protocol.lastReceived))
+}
+
+// records that data was received on the connection
+func (protocol *protocol) markReceived() {
+	now := time.Now()
+
+	atomic.StoreInt64(&protocol.lastReceived, now.UnixNano())
+
+	if neighbor := protocol.Neighbor; neighbor != nil {
+		neighbor.setLastSeen(now)
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region constants and variables //////////////////////////////////////////////////////////////////////////////////////
+
+var heartbeatInterval time.Duration
+
+var heartbeatMaxMissed int
+
+const (
+	HEARTBEAT_REQUEST  = byte(0)
+	HEARTBEAT_RESPONSE = byte(1)
+
+	MARSHALED_HEARTBEAT_TYPE_START = 0
+	MARSHALED_HEARTBEAT_TIME_START = MARSHALED_HEARTBEAT_TYPE_END
+
+	MARSHALED_HEARTBEAT_TYPE_SIZE = 1
+	MARSHALED_HEARTBEAT_TIME_SIZE = 8
+
+	MARSHALED_HEARTBEAT_TYPE_END = MARSHALED_HEARTBEAT_TYPE_START + MARSHALED_HEARTBEAT_TYPE_SIZE
+	MARSHALED_HEARTBEAT_TIME_END = MARSHALED_HEARTBEAT_TIME_START + MARSHALED_HEARTBEAT_TIME_SIZE
+
+	MARSHALED_HEARTBEAT_TOTAL_SIZE = MARSHALED_HEARTBEAT_TIME_END
+)
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,58 @@
+package gossip
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/accountability"
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/parameter"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestHeartbeat(t *testing.T) {
+	parameter.NodeConfig.Set(database.CFG_BACKEND, database.BACKEND_MEMORY)
+	defer database.Close()
+
+	neighbor := NewNeighbor(accountability.OwnId(), net.IPv4(127, 0, 0, 1), 0)
+	neighbors.Store(neighbor.GetIdentity().StringIdentifier, neighbor)
+	defer neighbors.Delete(neighbor.GetIdentity().StringIdentifier)
+
+	dialedProtocol, acceptedProtocol := completeHandshake(t, neighbor)
+	defer dialedProtocol.Conn.Close()
+	defer acceptedProtocol.Conn.Close()
+
+	// the handshake already counts as a sign of life
+	assert.Equal(t, neighbor.GetLastSeen().IsZero(), false)
+	assert.Equal(t, neighbor.GetRoundTripTime(), time.Duration(0))
+
+	receivedHeartbeat := make(chan time.Duration, 1)
+	onReceiveHeartbeat := events.NewClosure(func(neighbor *Neighbor, roundTripTime time.Duration) {
+		receivedHeartbeat <- roundTripTime
+	})
+	Events.ReceiveHeartbeat.Attach(onReceiveHeartbeat)
+	defer Events.ReceiveHeartbeat.Detach(onReceiveHeartbeat)
+
+	// the neighbor answers the request and we measure the round trip time of the response
+	if err := dialedProtocol.Send(newHeartbeatFrameV2(HEARTBEAT_REQUEST, time.Now())); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case roundTripTime := <-receivedHeartbeat:
+		assert.Equal(t, roundTripTime > 0, true)
+		assert.Equal(t, neighbor.GetRoundTripTime(), roundTripTime)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the heartbeat was not answered")
+	}
+
+	// the traffic of both connections is attributed to the neighbor
+	neighbor.trackConnection(dialedProtocol.Conn)
+	neighbor.trackConnection(acceptedProtocol.Conn)
+	statistics := neighbor.GetStatistics()
+	assert.Equal(t, statistics.BytesSent, statistics.BytesReceived)
+	assert.Equal(t, statistics.BytesSent, dialedProtocol.Conn.BytesWritten()+acceptedProtocol.Conn.BytesWritten())
+	assert.Equal(t, statistics.BytesSent > 0, true)
+}
@@ -0,0 +1,101 @@
+package gossip
+
+import (
+	"sync/atomic"
+
+	"github.com/iotaledger/goshimmer/packages/network"
+	"github.com/iotaledger/hive.go/events"
+)
+
+// NeighborStatistics contains the counters of the gossip traffic that was exchanged with a neighbor.
+type NeighborStatistics struct {
+	ReceivedTransactions  uint64
+	NewTransactions       uint64
+	DuplicateTransactions uint64
+	InvalidTransactions   uint64
+	SentTransactions      uint64
+	DroppedTransactions   uint64
+	DroppedRequests       uint64
+	BytesReceived         uint64
+	BytesSent             uint64
+}
+
+// Returns the current values of the traffic counters of the neighbor.
+func (neighbor *Neighbor) GetStatistics() NeighborStatistics {
+	bytesReceived, bytesSent := neighbor.getTraffic()
+
+	return NeighborStatistics{
+		ReceivedTransactions:  atomic.LoadUint64(&neighbor.receivedTransactions),
+		NewTransactions:       atomic.LoadUint64(&neighbor.newTransactions),
+		DuplicateTransactions: atomic.LoadUint64(&neighbor.duplicateTransactions),
+		InvalidTransactions:   atomic.LoadUint64(&neighbor.invalidTransactions),
+		SentTransactions:      atomic.LoadUint64(&neighbor.sentTransactions),
+		DroppedTransactions:   atomic.LoadUint64(&neighbor.droppedTransactions),
+		DroppedRequests:       atomic.LoadUint64(&neighbor.droppedRequests),
+		BytesReceived:         bytesReceived,
+		BytesSent:             bytesSent,
+	}
+}
+
+func (neighbor *Neighbor) increaseReceivedTransactionsCount() {
+	atomic.AddUint64(&neighbor.receivedTransactions, 1)
+}
+
+func (neighbor *Neighbor) increaseNewTransactionsCount() {
+	atomic.AddUint64(&neighbor.newTransactions, 1)
+}
+
+func (neighbor *Neighbor) increaseDuplicateTransactionsCount() {
+	atomic.AddUint64(&neighbor.duplicateTransactions, 1)
+}
+
+func (neighbor *Neighbor) increaseSentTransactionsCount() {
+	atomic.AddUint64(&neighbor.sentTransactions, 1)
+}
+
+func (neighbor *Neighbor) increaseDroppedTransactionsCount() {
+	atomic.AddUint64(&neighbor.droppedTransactions, 1)
+}
+
+func (neighbor *Neighbor) increaseDroppedRequestsCount() {
+	atomic.AddUint64(&neighbor.droppedRequests, 1)
+}
+
+// attributes the traffic of the connection to the neighbor (the traffic of closed connections is kept as well)
+func (neighbor *Neighbor) trackConnection(conn *network.ManagedConnection) {
+	neighbor.connectionsMutex.Lock()
+	defer neighbor.connectionsMutex.Unlock()
+
+	if neighbor.connections == nil {
+		neighbor.connections = make(map[*network.ManagedConnection]bool)
+	} else if neighbor.connections[conn] {
+		return
+	}
+	neighbor.connections[conn] = true
+
+	conn.Events.Close.Attach(events.NewClosure(func() {
+		neighbor.connectionsMutex.Lock()
+		defer neighbor.connectionsMutex.Unlock()
+
+		if neighbor.connections[conn] {
+			delete(neighbor.connections, conn)
+
+			neighbor.closedConnectionsBytesReceived += conn.BytesRead()
+			neighbor.closedConnectionsBytesSent += conn.BytesWritten()
+		}
+	}))
+}
+
+func (neighbor *Neighbor) getTraffic() (bytesReceived uint64, bytesSent uint64) {
+	neighbor.connectionsMutex.Lock()
+	defer neighbor.connectionsMutex.Unlock()
+
+	bytesReceived = neighbor.closedConnectionsBytesReceived
+	bytesSent = neighbor.closedConnectionsBytesSent
+	for conn := range neighbor.connections {
+		bytesReceived += conn.BytesRead()
+		bytesSent += conn.BytesWritten()
+	}
+
+	return
+}
@@ -1,10 +1,10 @@
 package gossip
 
 import (
-	"math"
 	"net"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/iotaledger/goshimmer/packages/accountability"
@@ -18,15 +18,15 @@ import (
 
 func configureNeighbors(plugin *node.Plugin) {
 	Events.AddNeighbor.Attach(events.NewClosure(func(neighbor *Neighbor) {
-		log.Infof("new neighbor added %s@%s:%d", neighbor.GetIdentity().StringIdentifier, neighbor.GetAddress().String(), neighbor.GetPort())
+		log.Infof("new neighbor added %s@%s:%d", neighbor.GetIdentity().StringIdentifier, neighbor.getHostOrAddress(), neighbor.GetPort())
 	}))
 
 	Events.UpdateNeighbor.Attach(events.NewClosure(func(neighbor *Neighbor) {
-		log.Infof("existing neighbor updated %s@%s:%d", neighbor.GetIdentity().StringIdentifier, neighbor.GetAddress().String(), neighbor.GetPort())
+		log.Infof("existing neighbor updated %s@%s:%d", neighbor.GetIdentity().StringIdentifier, neighbor.getHostOrAddress(), neighbor.GetPort())
 	}))
 
 	Events.RemoveNeighbor.Attach(events.NewClosure(func(neighbor *Neighbor) {
-		log.Infof("existing neighbor removed %s@%s:%d", neighbor.GetIdentity().StringIdentifier, neighbor.GetAddress().String(), neighbor.GetPort())
+		log.Infof("existing neighbor removed %s@%s:%d", neighbor.GetIdentity().StringIdentifier, neighbor.getHostOrAddress(), neighbor.GetPort())
 	}))
 }
 
@@ -50,54 +50,88 @@ func manageConnection(plugin *node.Plugin, neighbor *Neighbor) {
 	daemon.BackgroundWorker("Connection Manager ("+neighbor.GetIdentity().StringIdentifier+")", func() {
 		failedConnectionAttempts := 0
 
-		for _, exists := neighbors.Load(neighbor.GetIdentity().StringIdentifier); exists && failedConnectionAttempts < CONNECTION_MAX_ATTEMPTS; {
+		// static neighbors are reconnected until they get removed explicitly
+		for isManagedNeighbor(neighbor) && (neighbor.IsStatic() || failedConnectionAttempts < CONNECTION_MAX_ATTEMPTS) {
 			protocol, dialed, err := neighbor.Connect()
-			if err != nil {
-				failedConnectionAttempts++
+			if err == nil {
+				disconnectSignal := make(chan int, 1)
+				protocol.Conn.Events.Close.Attach(events.NewClosure(func() {
+					close(disconnectSignal)
+				}))
+
+				if dialed {
+					go protocol.Init()
+				}
 
-				log.Errorf("connection attempt [%d / %d] %s", failedConnectionAttempts, CONNECTION_MAX_ATTEMPTS, err.Error())
+				// wait for shutdown or
+				select {
+				case <-daemon.ShutdownSignal:
+					return
 
-				if failedConnectionAttempts <= CONNECTION_MAX_ATTEMPTS {
-					select {
-					case <-daemon.ShutdownSignal:
-						return
+				case <-disconnectSignal:
+				}
 
-					case <-time.After(time.Duration(int(math.Pow(2, float64(failedConnectionAttempts-1)))) * CONNECTION_BASE_TIMEOUT):
-						continue
-					}
+				// a neighbor that accepts the connection but rejects the handshake is not redialed immediately
+				if !protocol.isHandshakeCompleted() {
+					err = ErrConnectionFailed.Derive(errors.New("handshake failed"), "neighbor "+neighbor.GetIdentity().StringIdentifier+" closed the connection before completing the handshake")
 				}
 			}
 
-			failedConnectionAttempts = 0
-
-			disconnectSignal := make(chan int, 1)
-			protocol.Conn.Events.Close.Attach(events.NewClosure(func() {
-				close(disconnectSignal)
-			}))
+			if err != nil {
+				failedConnectionAttempts++
 
-			if dialed {
-				go protocol.Init()
+				if neighbor.IsStatic() {
+					log.Errorf("connection attempt [%d] %s", failedConnectionAttempts, err.Error())
+				} else {
+					log.Errorf("connection attempt [%d / %d] %s", failedConnectionAttempts, CONNECTION_MAX_ATTEMPTS, err.Error())
+				}
+			} else {
+				failedConnectionAttempts = 0
 			}
 
-			// wait for shutdown or
 			select {
 			case <-daemon.ShutdownSignal:
 				return
 
-			case <-disconnectSignal:
-				continue
+			case <-time.After(getConnectionTimeout(failedConnectionAttempts)):
 			}
 		}
 
-		RemoveNeighbor(neighbor.GetIdentity().StringIdentifier)
+		if isManagedNeighbor(neighbor) {
+			RemoveNeighbor(neighbor.GetIdentity().StringIdentifier)
+		}
 	})
 }
 
+// checks if the neighbor was not removed (or replaced by a new neighbor with the same identity) in the meantime
+func isManagedNeighbor(neighbor *Neighbor) bool {
+	currentNeighbor, exists := neighbors.Load(neighbor.GetIdentity().StringIdentifier)
+
+	return exists && currentNeighbor == neighbor
+}
+
+// returns the time to wait before the next connection attempt (the timeout doubles with every failed attempt but is
+// capped at CONNECTION_MAX_TIMEOUT)
+func getConnectionTimeout(failedConnectionAttempts int) time.Duration {
+	timeout := CONNECTION_BASE_TIMEOUT
+	for i := 1; i < failedConnectionAttempts && timeout < CONNECTION_MAX_TIMEOUT; i++ {
+		timeout *= 2
+	}
+
+	if timeout > CONNECTION_MAX_TIMEOUT {
+		return CONNECTION_MAX_TIMEOUT
+	}
+
+	return timeout
+}
+
 type Neighbor struct {
 	identity               *identity.Identity
 	identityMutex          sync.RWMutex
 	address                net.IP
 	addressMutex           sync.RWMutex
+	host                   string
+	hostMutex              sync.RWMutex
 	port                   uint16
 	portMutex              sync.RWMutex
 	initiatedProtocol      *protocol
@@ -105,6 +139,25 @@ type Neighbor struct {
 	acceptedProtocol       *protocol
 	Events                 neighborEvents
 	acceptedProtocolMutex  sync.RWMutex
+	invalidTransactions    uint64
+	protocolVersion        byte
+	protocolVersionMutex   sync.RWMutex
+	static                 bool
+	staticMutex            sync.RWMutex
+	lastSeen               int64
+	roundTripTime          int64
+
+	// traffic statistics
+	receivedTransactions           uint64
+	newTransactions                uint64
+	duplicateTransactions          uint64
+	sentTransactions               uint64
+	droppedTransactions            uint64
+	droppedRequests                uint64
+	connections                    map[*network.ManagedConnection]bool
+	closedConnectionsBytesReceived uint64
+	closedConnectionsBytesSent     uint64
+	connectionsMutex               sync.Mutex
 }
 
 func NewNeighbor(identity *identity.Identity, address net.IP, port uint16) *Neighbor {
@@ -118,6 +171,14 @@ func NewNeighbor(identity *identity.Identity, address net.IP, port uint16) *Neig
 	}
 }
 
+// Creates a neighbor that is never dropped by the autopeering or after failed connection attempts.
+func NewStaticNeighbor(identity *identity.Identity, address net.IP, port uint16) *Neighbor {
+	neighbor := NewNeighbor(identity, address, port)
+	neighbor.static = true
+
+	return neighbor
+}
+
 func (neighbor *Neighbor) GetIdentity() (result *identity.Identity) {
 	neighbor.identityMutex.RLock()
 	result = neighbor.identity
@@ -146,6 +207,30 @@ func (neighbor *Neighbor) SetAddress(address net.IP) {
 	neighbor.addressMutex.Unlock()
 }
 
+// Returns the host name of the neighbor (empty if the neighbor was defined by its ip address).
+func (neighbor *Neighbor) GetHost() (result string) {
+	neighbor.hostMutex.RLock()
+	result = neighbor.host
+	neighbor.hostMutex.RUnlock()
+
+	return result
+}
+
+func (neighbor *Neighbor) SetHost(host string) {
+	neighbor.hostMutex.Lock()
+	neighbor.host = host
+	neighbor.hostMutex.Unlock()
+}
+
+// returns the host name of the neighbor or its ip address if it was not defined by a host name
+func (neighbor *Neighbor) getHostOrAddress() string {
+	if host := neighbor.GetHost(); host != "" {
+		return host
+	}
+
+	return neighbor.GetAddress().String()
+}
+
 func (neighbor *Neighbor) GetPort() (result uint16) {
 	neighbor.portMutex.RLock()
 	result = neighbor.port
@@ -188,6 +273,48 @@ func (neighbor *Neighbor) SetAcceptedProtocol(p *protocol) {
 	neighbor.acceptedProtocolMutex.Unlock()
 }
 
+// Returns the amount of transactions that were dropped because their weight magnitude was too low.
+func (neighbor *Neighbor) GetInvalidTransactionsCount() uint64 {
+	return atomic.LoadUint64(&neighbor.invalidTransactions)
+}
+
+func (neighbor *Neighbor) increaseInvalidTransactionsCount() {
+	atomic.AddUint64(&neighbor.invalidTransactions, 1)
+}
+
+// Returns true if the neighbor was configured manually (and is not managed by the autopeering).
+func (neighbor *Neighbor) IsStatic() (result bool) {
+	neighbor.staticMutex.RLock()
+	result = neighbor.static
+	neighbor.staticMutex.RUnlock()
+
+	return result
+}
+
+func (neighbor *Neighbor) setStatic(static bool) {
+	neighbor.staticMutex.Lock()
+	neighbor.static = static
+	neighbor.staticMutex.Unlock()
+}
+
+// returns the protocol version that is proposed when dialing the neighbor
+func (neighbor *Neighbor) getProtocolVersion() byte {
+	neighbor.protocolVersionMutex.RLock()
+	defer neighbor.protocolVersionMutex.RUnlock()
+
+	if neighbor.protocolVersion == 0 {
+		return DEFAULT_PROTOCOL.version
+	}
+
+	return neighbor.protocolVersion
+}
+
+func (neighbor *Neighbor) setProtocolVersion(version byte) {
+	neighbor.protocolVersionMutex.Lock()
+	neighbor.protocolVersion = version
+	neighbor.protocolVersionMutex.Unlock()
+}
+
 func UnmarshalPeer(data []byte) (*Neighbor, error) {
 	return &Neighbor{}, nil
 }
@@ -203,6 +330,11 @@ func (neighbor *Neighbor) Connect() (*protocol, bool, errors.IdentifiableError)
 		return neighbor.GetAcceptedProtocol(), false, nil
 	}
 
+	// the host is resolved before every attempt, so the neighbor can change its ip address
+	if err := neighbor.resolveHost(); err != nil {
+		return nil, false, err
+	}
+
 	// otherwise try to dial
 	conn, err := net.Dial("tcp", neighbor.GetAddress().String()+":"+strconv.Itoa(int(neighbor.GetPort())))
 	if err != nil {
@@ -210,7 +342,10 @@ func (neighbor *Neighbor) Connect() (*protocol, bool, errors.IdentifiableError)
 			neighbor.GetIdentity().StringIdentifier+"@"+neighbor.GetAddress().String()+":"+strconv.Itoa(int(neighbor.GetPort())))
 	}
 
-	neighbor.SetInitiatedProtocol(newProtocol(network.NewManagedConnection(conn)))
+	initiatedProtocol := newProtocol(network.NewManagedConnection(conn))
+	initiatedProtocol.dialedNeighbor = neighbor
+	neighbor.SetInitiatedProtocol(initiatedProtocol)
+	neighbor.trackConnection(initiatedProtocol.Conn)
 
 	neighbor.GetInitiatedProtocol().Conn.Events.Close.Attach(events.NewClosure(func() {
 		neighbor.SetInitiatedProtocol(nil)
@@ -235,13 +370,41 @@ func (neighbor *Neighbor) Connect() (*protocol, bool, errors.IdentifiableError)
 	return neighbor.GetInitiatedProtocol(), true, nil
 }
 
+// closes the initiated and the accepted connection of the neighbor
+func (neighbor *Neighbor) disconnect() {
+	if initiatedProtocol := neighbor.GetInitiatedProtocol(); initiatedProtocol != nil {
+		_ = initiatedProtocol.Conn.Close()
+	}
+
+	if acceptedProtocol := neighbor.GetAcceptedProtocol(); acceptedProtocol != nil {
+		_ = acceptedProtocol.Conn.Close()
+	}
+}
+
+// updates the address of neighbors that were defined by their host name
+func (neighbor *Neighbor) resolveHost() errors.IdentifiableError {
+	host := neighbor.GetHost()
+	if host == "" {
+		return nil
+	}
+
+	resolvedAddress, err := net.ResolveIPAddr("ip", host)
+	if err != nil {
+		return ErrConnectionFailed.Derive(err, "failed to resolve host of neighbor "+neighbor.GetIdentity().StringIdentifier+"@"+host)
+	}
+	neighbor.SetAddress(resolvedAddress.IP)
+
+	return nil
+}
+
 func (neighbor *Neighbor) Marshal() []byte {
 	return nil
 }
 
 func (neighbor *Neighbor) Equals(other *Neighbor) bool {
 	return neighbor.GetIdentity().StringIdentifier == other.GetIdentity().StringIdentifier &&
-		neighbor.GetPort() == other.GetPort() && neighbor.GetAddress().String() == other.GetAddress().String()
+		neighbor.GetPort() == other.GetPort() && neighbor.GetHost() == other.GetHost() &&
+		neighbor.GetAddress().String() == other.GetAddress().String()
 }
 
 func AddNeighbor(newNeighbor *Neighbor) {
@@ -249,18 +412,32 @@ func AddNeighbor(newNeighbor *Neighbor) {
 		neighbors.Store(newNeighbor.GetIdentity().StringIdentifier, newNeighbor)
 		Events.AddNeighbor.Trigger(newNeighbor)
 	} else {
+		// only static neighbors can update static neighbors, so the autopeering can not modify them
+		if neighbor.IsStatic() && !newNeighbor.IsStatic() {
+			return
+		}
+
+		if newNeighbor.IsStatic() {
+			neighbor.setStatic(true)
+		}
+
+		// the identity is kept, since both neighbors share the identifier and static definitions do not contain the
+		// public key of the neighbor
 		if !neighbor.Equals(newNeighbor) {
-			neighbor.SetIdentity(newNeighbor.GetIdentity())
 			neighbor.SetPort(newNeighbor.GetPort())
 			neighbor.SetAddress(newNeighbor.GetAddress())
+			neighbor.SetHost(newNeighbor.GetHost())
 
 			Events.UpdateNeighbor.Trigger(neighbor)
 		}
 	}
 }
 
+// Removes the neighbor and closes its connections.
 func RemoveNeighbor(identifier string) {
 	if neighbor, exists := neighbors.Delete(identifier); exists {
+		neighbor.disconnect()
+
 		Events.RemoveNeighbor.Trigger(neighbor)
 	}
 }
@@ -276,6 +453,7 @@ func GetNeighbors() map[string]*Neighbor {
 const (
 	CONNECTION_MAX_ATTEMPTS = 5
 	CONNECTION_BASE_TIMEOUT = 10 * time.Second
+	CONNECTION_MAX_TIMEOUT  = 5 * time.Minute
 )
 
 var neighbors = NewNeighborMap()
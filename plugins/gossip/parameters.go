@@ -1,13 +1,23 @@
 package gossip
 
 import (
+	"time"
+
 	flag "github.com/spf13/pflag"
 )
 
 const (
-	GOSSIP_PORT = "gossip.port"
+	GOSSIP_PORT                 = "gossip.port"
+	GOSSIP_MIN_WEIGHT_MAGNITUDE = "gossip.minWeightMagnitude"
+	GOSSIP_HEARTBEAT_INTERVAL   = "gossip.heartbeatInterval"
+	GOSSIP_HEARTBEAT_MAX_MISSED = "gossip.heartbeatMaxMissed"
+	GOSSIP_NEIGHBORS            = "gossip.neighbors"
 )
 
 func init() {
 	flag.Int(GOSSIP_PORT, 14666, "tcp port for gossip connection")
+	flag.Int(GOSSIP_MIN_WEIGHT_MAGNITUDE, 9, "minimum weight magnitude (amount of trailing zero trits in the hash) of received transactions")
+	flag.Duration(GOSSIP_HEARTBEAT_INTERVAL, 5*time.Second, "interval in which heartbeats are sent to the neighbors (0 disables the heartbeats)")
+	flag.Int(GOSSIP_HEARTBEAT_MAX_MISSED, 3, "amount of heartbeat intervals without any message from a neighbor before the connection is dropped (0 disables the check)")
+	flag.StringSlice(GOSSIP_NEIGHBORS, []string{}, "list of static neighbors (identity@host:port) that are not managed by the autopeering")
 }
@@ -12,10 +12,16 @@ func configure(plugin *node.Plugin) {
 	configureNeighbors(plugin)
 	configureServer(plugin)
 	configureSendQueue(plugin)
+	configureTransactionRequester(plugin)
+	configureTransactionProcessor(plugin)
+	configureHeartbeats(plugin)
+	configureStaticNeighbors(plugin)
 }
 
 func run(plugin *node.Plugin) {
 	runNeighbors(plugin)
+	runStaticNeighbors(plugin)
 	runServer(plugin)
 	runSendQueue(plugin)
+	runTransactionRequester(plugin)
 }
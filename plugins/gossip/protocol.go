@@ -12,8 +12,18 @@ import (
 // region constants and variables //////////////////////////////////////////////////////////////////////////////////////
 
 var DEFAULT_PROTOCOL = protocolDefinition{
-	version:     VERSION_1,
-	initializer: protocolV1,
+	version:      VERSION_2,
+	initializer:  protocolV2,
+	initialState: newFrameStateV2,
+}
+
+var SUPPORTED_PROTOCOLS = map[byte]protocolDefinition{
+	VERSION_1: {
+		version:      VERSION_1,
+		initializer:  protocolV1,
+		initialState: newIndentificationStateV1,
+	},
+	VERSION_2: DEFAULT_PROTOCOL,
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -23,6 +33,8 @@ var DEFAULT_PROTOCOL = protocolDefinition{
 type protocol struct {
 	Conn                      *network.ManagedConnection
 	Neighbor                  *Neighbor
+	dialedNeighbor            *Neighbor
+	lastReceived              int64
 	Version                   byte
 	sendHandshakeCompleted    bool
 	receiveHandshakeCompleted bool
@@ -42,6 +54,8 @@ func newProtocol(conn *network.ManagedConnection) *protocol {
 			ReceiveConnectionAccepted: events.NewEvent(events.CallbackCaller),
 			ReceiveConnectionRejected: events.NewEvent(events.CallbackCaller),
 			ReceiveTransactionData:    events.NewEvent(dataCaller),
+			ReceiveRequestData:        events.NewEvent(dataCaller),
+			ReceiveHeartbeatData:      events.NewEvent(dataCaller),
 			HandshakeCompleted:        events.NewEvent(events.CallbackCaller),
 			Error:                     events.NewEvent(errorCaller),
 		},
@@ -69,9 +83,13 @@ func (protocol *protocol) Init() {
 	})
 	var onClose *events.Closure
 	onClose = events.NewClosure(func() {
-		protocol.Conn.Events.ReceiveData.Detach(onReceiveData)
-		protocol.Conn.Events.Close.Detach(onClose)
-		protocol.Events.ReceiveConnectionAccepted.Detach(onConnectionAccepted)
+		// the connection is usually closed while one of these events is triggered, which holds the lock of the event,
+		// so we detach the handlers asynchronously
+		go func() {
+			protocol.Conn.Events.ReceiveData.Detach(onReceiveData)
+			protocol.Conn.Events.Close.Detach(onClose)
+			protocol.Events.ReceiveConnectionAccepted.Detach(onConnectionAccepted)
+		}()
 	})
 
 	// region register event handlers
@@ -79,27 +97,52 @@ func (protocol *protocol) Init() {
 	protocol.Conn.Events.Close.Attach(onClose)
 	protocol.Events.ReceiveConnectionAccepted.Attach(onConnectionAccepted)
 
-	// send protocol version
-	if err := protocol.Send(DEFAULT_PROTOCOL.version); err != nil {
-		return
+	// the dialing side proposes the protocol version and the accepting side answers once it received the proposal
+	if protocol.dialedNeighbor != nil {
+		if err := protocol.initialize(protocol.dialedNeighbor.getProtocolVersion()); err != nil {
+			return
+		}
+	}
+
+	// start reading from the connection
+	_, _ = protocol.Conn.Read(make([]byte, 1000))
+}
+
+// returns true if both sides accepted the connection
+func (protocol *protocol) isHandshakeCompleted() bool {
+	protocol.handshakeMutex.Lock()
+	defer protocol.handshakeMutex.Unlock()
+
+	return protocol.sendHandshakeCompleted && protocol.receiveHandshakeCompleted
+}
+
+// sends the protocol version and starts the handshake of the corresponding protocol
+func (protocol *protocol) initialize(version byte) errors.IdentifiableError {
+	definition, supported := SUPPORTED_PROTOCOLS[version]
+	if !supported {
+		return ErrInvalidSendParam.Derive("unsupported protocol version (" + strconv.Itoa(int(version)) + ")")
+	}
+
+	if err := protocol.Send(version); err != nil {
+		return err
 	}
 
-	// initialize default protocol
-	if err := DEFAULT_PROTOCOL.initializer(protocol); err != nil {
+	if err := definition.initializer(protocol); err != nil {
 		protocol.SendState = nil
 
 		_ = protocol.Conn.Close()
 
 		protocol.Events.Error.Trigger(err)
 
-		return
+		return err
 	}
 
-	// start reading from the connection
-	_, _ = protocol.Conn.Read(make([]byte, 1000))
+	return nil
 }
 
 func (protocol *protocol) Receive(data []byte) {
+	protocol.markReceived()
+
 	offset := 0
 	length := len(data)
 	for offset < length && protocol.ReceivingState != nil {
@@ -146,34 +189,53 @@ type versionState struct {
 	protocol *protocol
 }
 
+// The dialing side sends the highest version it supports (or the version that the neighbor is known to speak) and the
+// accepting side answers with the same version. Nodes that only speak version 1 always answer with version 1 - in this
+// case the dialing side remembers the version of the neighbor and reconnects using version 1.
 func (state *versionState) Receive(data []byte, offset int, length int) (int, errors.IdentifiableError) {
-	switch data[offset] {
-	case 1:
-		protocol := state.protocol
+	version := data[offset]
 
-		protocol.Version = 1
-		protocol.Events.ReceiveVersion.Trigger(1)
+	definition, supported := SUPPORTED_PROTOCOLS[version]
+	if !supported {
+		return 1, ErrInvalidStateTransition.Derive("invalid version state transition (" + strconv.Itoa(int(version)) + ")")
+	}
 
-		protocol.ReceivingState = newIndentificationStateV1(protocol)
+	protocol := state.protocol
 
-		return 1, nil
+	if dialedNeighbor := protocol.dialedNeighbor; dialedNeighbor != nil {
+		if version != protocol.Version {
+			dialedNeighbor.setProtocolVersion(version)
+
+			_ = protocol.Conn.Close()
 
-	default:
-		return 1, ErrInvalidStateTransition.Derive("invalid version state transition (" + strconv.Itoa(int(data[offset])) + ")")
+			protocol.ReceivingState = nil
+
+			return 1, nil
+		}
+	} else if err := protocol.initialize(version); err != nil {
+		protocol.ReceivingState = nil
+
+		return 1, nil
 	}
+
+	protocol.Events.ReceiveVersion.Trigger(int(version))
+
+	protocol.ReceivingState = definition.initialState(protocol)
+
+	return 1, nil
 }
 
 func (state *versionState) Send(param interface{}) errors.IdentifiableError {
 	if version, ok := param.(byte); ok {
-		switch version {
-		case VERSION_1:
+		if definition, supported := SUPPORTED_PROTOCOLS[version]; supported {
 			protocol := state.protocol
 
 			if _, err := protocol.Conn.Write([]byte{version}); err != nil {
 				return ErrSendFailed.Derive(err, "failed to send version byte")
 			}
 
-			protocol.SendState = newIndentificationStateV1(protocol)
+			protocol.Version = version
+			protocol.SendState = definition.initialState(protocol)
 
 			return nil
 		}
@@ -192,8 +254,9 @@ type protocolState interface {
 }
 
 type protocolDefinition struct {
-	version     byte
-	initializer func(*protocol) errors.IdentifiableError
+	version      byte
+	initializer  func(*protocol) errors.IdentifiableError
+	initialState func(*protocol) protocolState
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -9,8 +9,10 @@ import (
 	"github.com/iotaledger/goshimmer/packages/errors"
 	"github.com/iotaledger/goshimmer/packages/identity"
 	"github.com/iotaledger/goshimmer/packages/model/meta_transaction"
+	"github.com/iotaledger/goshimmer/packages/typeutils"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/trinary"
 )
 
 // region protocolV1 ///////////////////////////////////////////////////////////////////////////////////////////////////
@@ -45,15 +47,33 @@ func protocolV1(protocol *protocol) errors.IdentifiableError {
 	return nil
 }
 
-func sendTransactionV1(protocol *protocol, tx *meta_transaction.MetaTransaction) {
+func sendTransactionV1(protocol *protocol, tx *meta_transaction.MetaTransaction) bool {
 	if _, ok := protocol.SendState.(*dispatchStateV1); ok {
 		protocol.sendMutex.Lock()
 		defer protocol.sendMutex.Unlock()
 
 		if err := protocol.send(DISPATCH_TRANSACTION); err != nil {
-			return
+			return false
 		}
 		if err := protocol.send(tx); err != nil {
+			return false
+		}
+
+		return true
+	}
+
+	return false
+}
+
+func sendTransactionRequestV1(protocol *protocol, transactionHash trinary.Trytes) {
+	if _, ok := protocol.SendState.(*dispatchStateV1); ok {
+		protocol.sendMutex.Lock()
+		defer protocol.sendMutex.Unlock()
+
+		if err := protocol.send(DISPATCH_REQUEST); err != nil {
+			return
+		}
+		if err := protocol.send(transactionHash); err != nil {
 			return
 		}
 	}
@@ -69,7 +89,7 @@ type indentificationStateV1 struct {
 	offset   int
 }
 
-func newIndentificationStateV1(protocol *protocol) *indentificationStateV1 {
+func newIndentificationStateV1(protocol *protocol) protocolState {
 	return &indentificationStateV1{
 		protocol: protocol,
 		buffer:   make([]byte, MARSHALED_IDENTITY_TOTAL_SIZE),
@@ -220,7 +240,7 @@ func newDispatchStateV1(protocol *protocol) *dispatchStateV1 {
 }
 
 func (state *dispatchStateV1) Receive(data []byte, offset int, length int) (int, errors.IdentifiableError) {
-	switch data[0] {
+	switch data[offset] {
 	case DISPATCH_DROP:
 		protocol := state.protocol
 
@@ -281,7 +301,7 @@ func (state *dispatchStateV1) Send(param interface{}) errors.IdentifiableError {
 				return ErrSendFailed.Derive(err, "failed to send request dispatch byte")
 			}
 
-			protocol.SendState = newTransactionStateV1(protocol)
+			protocol.SendState = newRequestStateV1(protocol)
 
 			return nil
 		}
@@ -320,7 +340,7 @@ func (state *transactionStateV1) Receive(data []byte, offset int, length int) (i
 
 		protocol.Events.ReceiveTransactionData.Trigger(transactionData)
 
-		go ProcessReceivedTransactionData(transactionData)
+		go ProcessReceivedTransactionData(transactionData, protocol.Neighbor)
 
 		protocol.ReceivingState = newDispatchStateV1(protocol)
 		state.offset = 0
@@ -350,23 +370,56 @@ func (state *transactionStateV1) Send(param interface{}) errors.IdentifiableErro
 // region requestStateV1 ///////////////////////////////////////////////////////////////////////////////////////////////
 
 type requestStateV1 struct {
-	buffer []byte
-	offset int
+	protocol *protocol
+	buffer   []byte
+	offset   int
 }
 
 func newRequestStateV1(protocol *protocol) *requestStateV1 {
 	return &requestStateV1{
-		buffer: make([]byte, 1),
-		offset: 0,
+		protocol: protocol,
+		buffer:   make([]byte, MARSHALED_TRANSACTION_REQUEST_SIZE),
+		offset:   0,
 	}
 }
 
 func (state *requestStateV1) Receive(data []byte, offset int, length int) (int, errors.IdentifiableError) {
-	return 0, nil
+	bytesRead := byteutils.ReadAvailableBytesToBuffer(state.buffer, state.offset, data, offset, length)
+
+	state.offset += bytesRead
+	if state.offset == MARSHALED_TRANSACTION_REQUEST_SIZE {
+		protocol := state.protocol
+
+		requestData := make([]byte, MARSHALED_TRANSACTION_REQUEST_SIZE)
+		copy(requestData, state.buffer)
+
+		protocol.Events.ReceiveRequestData.Trigger(requestData)
+
+		if protocol.Neighbor != nil {
+			go Events.ReceiveTransactionRequest.Trigger(protocol.Neighbor, trinary.Trytes(typeutils.BytesToString(requestData)))
+		}
+
+		protocol.ReceivingState = newDispatchStateV1(protocol)
+		state.offset = 0
+	}
+
+	return bytesRead, nil
 }
 
 func (state *requestStateV1) Send(param interface{}) errors.IdentifiableError {
-	return nil
+	if transactionHash, ok := param.(trinary.Trytes); ok && len(transactionHash) == MARSHALED_TRANSACTION_REQUEST_SIZE {
+		protocol := state.protocol
+
+		if _, err := protocol.Conn.Write(typeutils.StringToBytes(transactionHash)); err != nil {
+			return ErrSendFailed.Derive(err, "failed to send transaction request")
+		}
+
+		protocol.SendState = newDispatchStateV1(protocol)
+
+		return nil
+	}
+
+	return ErrInvalidSendParam.Derive("passed in parameter is not a valid transaction hash")
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -393,6 +446,8 @@ const (
 	MARSHALED_IDENTITY_SIGNATURE_END = MARSHALED_IDENTITY_SIGNATURE_START + MARSHALED_IDENTITY_SIGNATURE_SIZE
 
 	MARSHALED_IDENTITY_TOTAL_SIZE = MARSHALED_IDENTITY_SIGNATURE_END
+
+	MARSHALED_TRANSACTION_REQUEST_SIZE = 81
 )
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
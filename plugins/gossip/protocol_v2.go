@@ -0,0 +1,309 @@
+package gossip
+
+import (
+	"encoding/binary"
+	"strconv"
+
+	"github.com/iotaledger/goshimmer/packages/accountability"
+	"github.com/iotaledger/goshimmer/packages/byteutils"
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/goshimmer/packages/identity"
+	"github.com/iotaledger/goshimmer/packages/model/meta_transaction"
+	"github.com/iotaledger/goshimmer/packages/typeutils"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/trinary"
+)
+
+// region protocolV2 ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Version 2 of the protocol sends all messages (including the handshake) as frames. Every frame starts with the length
+// of its payload and the type of the message, so the receiver can skip the message types that it does not know.
+func protocolV2(protocol *protocol) errors.IdentifiableError {
+	if err := protocol.Send(newIdentificationFrameV2(accountability.OwnId())); err != nil {
+		return err
+	}
+
+	onReceiveIdentification := events.NewClosure(func(identity *identity.Identity) {
+		if protocol.Neighbor == nil {
+			if err := protocol.Send(newFrameV2(MESSAGE_TYPE_ACCEPTANCE, []byte{CONNECTION_REJECT})); err != nil {
+				return
+			}
+
+			_ = protocol.Conn.Close()
+		} else {
+			if err := protocol.Send(newFrameV2(MESSAGE_TYPE_ACCEPTANCE, []byte{CONNECTION_ACCEPT})); err != nil {
+				return
+			}
+
+			protocol.handshakeMutex.Lock()
+			defer protocol.handshakeMutex.Unlock()
+
+			protocol.sendHandshakeCompleted = true
+			if protocol.receiveHandshakeCompleted {
+				protocol.Events.HandshakeCompleted.Trigger()
+			}
+		}
+	})
+
+	protocol.Events.ReceiveIdentification.Attach(onReceiveIdentification)
+	protocol.Events.ReceiveHeartbeatData.Attach(events.NewClosure(func(data []byte) {
+		receiveHeartbeatV2(protocol, data)
+	}))
+
+	return nil
+}
+
+func sendTransactionV2(protocol *protocol, tx *meta_transaction.MetaTransaction) bool {
+	if _, ok := protocol.SendState.(*frameStateV2); ok {
+		return protocol.Send(newFrameV2(MESSAGE_TYPE_TRANSACTION, tx.GetBytes())) == nil
+	}
+
+	return false
+}
+
+func sendTransactionRequestV2(protocol *protocol, transactionHash trinary.Trytes) {
+	if _, ok := protocol.SendState.(*frameStateV2); ok && len(transactionHash) == MARSHALED_TRANSACTION_REQUEST_SIZE {
+		_ = protocol.Send(newFrameV2(MESSAGE_TYPE_TRANSACTION_REQUEST, typeutils.StringToBytes(transactionHash)))
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region frameV2 //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type frameV2 struct {
+	messageType byte
+	payload     []byte
+}
+
+func newFrameV2(messageType byte, payload []byte) *frameV2 {
+	return &frameV2{
+		messageType: messageType,
+		payload:     payload,
+	}
+}
+
+func newIdentificationFrameV2(id *identity.Identity) *frameV2 {
+	signature, err := id.Sign(id.Identifier)
+	if err != nil {
+		return nil
+	}
+
+	payload := make([]byte, MARSHALED_IDENTITY_TOTAL_SIZE)
+	copy(payload[MARSHALED_IDENTITY_START:MARSHALED_IDENTITY_END], id.Identifier)
+	copy(payload[MARSHALED_IDENTITY_SIGNATURE_START:MARSHALED_IDENTITY_SIGNATURE_END], signature)
+
+	return newFrameV2(MESSAGE_TYPE_IDENTIFICATION, payload)
+}
+
+func (frame *frameV2) Marshal() []byte {
+	result := make([]byte, FRAME_HEADER_SIZE+len(frame.payload))
+	binary.BigEndian.PutUint32(result[FRAME_LENGTH_START:FRAME_LENGTH_END], uint32(len(frame.payload)))
+	result[FRAME_MESSAGE_TYPE_START] = frame.messageType
+	copy(result[FRAME_HEADER_SIZE:], frame.payload)
+
+	return result
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region frameStateV2 /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// frameStateV2 reads the frames of the connection and dispatches them according to their message type. The handshake
+// (identification and acceptance) has to be completed before any other message is accepted.
+type frameStateV2 struct {
+	protocol   *protocol
+	header     []byte
+	payload    []byte
+	offset     int
+	identified bool
+	accepted   bool
+}
+
+func newFrameStateV2(protocol *protocol) protocolState {
+	return &frameStateV2{
+		protocol: protocol,
+		header:   make([]byte, FRAME_HEADER_SIZE),
+	}
+}
+
+func (state *frameStateV2) Receive(data []byte, offset int, length int) (int, errors.IdentifiableError) {
+	// read the header first
+	if state.payload == nil {
+		bytesRead := byteutils.ReadAvailableBytesToBuffer(state.header, state.offset, data, offset, length)
+
+		state.offset += bytesRead
+		if state.offset == FRAME_HEADER_SIZE {
+			payloadSize := binary.BigEndian.Uint32(state.header[FRAME_LENGTH_START:FRAME_LENGTH_END])
+			if payloadSize > MAX_FRAME_PAYLOAD_SIZE {
+				return bytesRead, ErrInvalidFrame.Derive("frame exceeds the maximum payload size (" + strconv.Itoa(int(payloadSize)) + ")")
+			}
+
+			state.payload = make([]byte, payloadSize)
+			state.offset = 0
+		} else {
+			return bytesRead, nil
+		}
+
+		if len(state.payload) != 0 {
+			return bytesRead, nil
+		}
+
+		return bytesRead, state.dispatch()
+	}
+
+	bytesRead := byteutils.ReadAvailableBytesToBuffer(state.payload, state.offset, data, offset, length)
+
+	state.offset += bytesRead
+	if state.offset == len(state.payload) {
+		return bytesRead, state.dispatch()
+	}
+
+	return bytesRead, nil
+}
+
+func (state *frameStateV2) Send(param interface{}) errors.IdentifiableError {
+	if frame, ok := param.(*frameV2); ok && frame != nil {
+		if len(frame.payload) > MAX_FRAME_PAYLOAD_SIZE {
+			return ErrInvalidSendParam.Derive("frame exceeds the maximum payload size")
+		}
+
+		protocol := state.protocol
+
+		if _, err := protocol.Conn.Write(frame.Marshal()); err != nil {
+			return ErrSendFailed.Derive(err, "failed to send message of type "+strconv.Itoa(int(frame.messageType)))
+		}
+
+		if frame.messageType == MESSAGE_TYPE_DROP || (frame.messageType == MESSAGE_TYPE_ACCEPTANCE && len(frame.payload) == 1 && frame.payload[0] == CONNECTION_REJECT) {
+			protocol.SendState = nil
+		}
+
+		return nil
+	}
+
+	return ErrInvalidSendParam.Derive("passed in parameter is not a valid frame")
+}
+
+// processes the completely received frame and resets the state for the next one
+func (state *frameStateV2) dispatch() errors.IdentifiableError {
+	messageType := state.header[FRAME_MESSAGE_TYPE_START]
+	payload := state.payload
+
+	state.payload = nil
+	state.offset = 0
+
+	protocol := state.protocol
+
+	switch messageType {
+	case MESSAGE_TYPE_IDENTIFICATION:
+		if state.identified || len(payload) != MARSHALED_IDENTITY_TOTAL_SIZE {
+			return ErrInvalidStateTransition.Derive("unexpected identification message")
+		}
+
+		receivedIdentity, err := unmarshalIdentity(payload)
+		if err != nil {
+			return ErrInvalidAuthenticationMessage.Derive(err, "invalid authentication message")
+		}
+
+		if neighbor, exists := neighbors.Load(receivedIdentity.StringIdentifier); exists {
+			protocol.Neighbor = neighbor
+		} else {
+			protocol.Neighbor = nil
+		}
+		state.identified = true
+
+		protocol.Events.ReceiveIdentification.Trigger(receivedIdentity)
+
+	case MESSAGE_TYPE_ACCEPTANCE:
+		if state.accepted || len(payload) != 1 {
+			return ErrInvalidStateTransition.Derive("unexpected acceptance message")
+		}
+
+		switch payload[0] {
+		case CONNECTION_REJECT:
+			protocol.Events.ReceiveConnectionRejected.Trigger()
+
+			_ = protocol.Conn.Close()
+
+			protocol.ReceivingState = nil
+
+		case CONNECTION_ACCEPT:
+			state.accepted = true
+
+			protocol.Events.ReceiveConnectionAccepted.Trigger()
+
+		default:
+			return ErrInvalidStateTransition.Derive("invalid acceptance state transition (" + strconv.Itoa(int(payload[0])) + ")")
+		}
+
+	case MESSAGE_TYPE_DROP:
+		protocol.Events.ReceiveConnectionRejected.Trigger()
+
+		_ = protocol.Conn.Close()
+
+		protocol.ReceivingState = nil
+
+	case MESSAGE_TYPE_TRANSACTION:
+		if !state.accepted || len(payload) != meta_transaction.MARSHALED_TOTAL_SIZE/consts.NumberOfTritsInAByte {
+			return ErrInvalidStateTransition.Derive("unexpected transaction message")
+		}
+
+		protocol.Events.ReceiveTransactionData.Trigger(payload)
+
+		go ProcessReceivedTransactionData(payload, protocol.Neighbor)
+
+	case MESSAGE_TYPE_TRANSACTION_REQUEST:
+		if !state.accepted || len(payload) != MARSHALED_TRANSACTION_REQUEST_SIZE {
+			return ErrInvalidStateTransition.Derive("unexpected transaction request message")
+		}
+
+		protocol.Events.ReceiveRequestData.Trigger(payload)
+
+		if protocol.Neighbor != nil {
+			go Events.ReceiveTransactionRequest.Trigger(protocol.Neighbor, trinary.Trytes(typeutils.BytesToString(payload)))
+		}
+
+	case MESSAGE_TYPE_HEARTBEAT:
+		if !state.accepted {
+			return ErrInvalidStateTransition.Derive("unexpected heartbeat message")
+		}
+
+		protocol.Events.ReceiveHeartbeatData.Trigger(payload)
+
+	default:
+		// message types of newer protocol revisions are skipped
+	}
+
+	return nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region constants and variables //////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	VERSION_2 = byte(2)
+
+	MESSAGE_TYPE_IDENTIFICATION      = byte(0)
+	MESSAGE_TYPE_ACCEPTANCE          = byte(1)
+	MESSAGE_TYPE_DROP                = byte(2)
+	MESSAGE_TYPE_TRANSACTION         = byte(3)
+	MESSAGE_TYPE_TRANSACTION_REQUEST = byte(4)
+	MESSAGE_TYPE_HEARTBEAT           = byte(5)
+
+	FRAME_LENGTH_START       = 0
+	FRAME_MESSAGE_TYPE_START = FRAME_LENGTH_END
+
+	FRAME_LENGTH_SIZE       = 4
+	FRAME_MESSAGE_TYPE_SIZE = 1
+
+	FRAME_LENGTH_END       = FRAME_LENGTH_START + FRAME_LENGTH_SIZE
+	FRAME_MESSAGE_TYPE_END = FRAME_MESSAGE_TYPE_START + FRAME_MESSAGE_TYPE_SIZE
+
+	FRAME_HEADER_SIZE = FRAME_MESSAGE_TYPE_END
+
+	MAX_FRAME_PAYLOAD_SIZE = 64 * 1024
+)
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
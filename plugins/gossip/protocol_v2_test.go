@@ -0,0 +1,175 @@
+package gossip
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/accountability"
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/goshimmer/packages/network"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/parameter"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestFrameStateV2(t *testing.T) {
+	protocol := newProtocol(nil)
+	state := newFrameStateV2(protocol)
+
+	var receivedRequests [][]byte
+	protocol.Events.ReceiveRequestData.Attach(events.NewClosure(func(data []byte) {
+		receivedRequests = append(receivedRequests, data)
+	}))
+
+	request := make([]byte, MARSHALED_TRANSACTION_REQUEST_SIZE)
+	for i := range request {
+		request[i] = '9'
+	}
+
+	// requests are only accepted after the handshake
+	err := receiveFragmented(state, newFrameV2(MESSAGE_TYPE_TRANSACTION_REQUEST, request).Marshal(), 1000)
+	assert.Equal(t, ErrInvalidStateTransition.Equals(err), true)
+
+	state = newFrameStateV2(protocol)
+
+	// frames are reassembled from arbitrary fragments and unknown message types are skipped
+	data := newFrameV2(MESSAGE_TYPE_ACCEPTANCE, []byte{CONNECTION_ACCEPT}).Marshal()
+	data = append(data, newFrameV2(42, []byte("future message")).Marshal()...)
+	data = append(data, newFrameV2(MESSAGE_TYPE_TRANSACTION_REQUEST, request).Marshal()...)
+	if err := receiveFragmented(state, data, 3); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, receivedRequests, [][]byte{request})
+
+	// oversized frames are rejected
+	header := newFrameV2(MESSAGE_TYPE_TRANSACTION, nil).Marshal()
+	header[FRAME_LENGTH_START] = 0xff
+	err = receiveFragmented(state, header, len(header))
+	assert.Equal(t, ErrInvalidFrame.Equals(err), true)
+}
+
+// passes the data to the state in chunks of the given size
+func receiveFragmented(state protocolState, data []byte, fragmentSize int) errors.IdentifiableError {
+	for offset := 0; offset < len(data); {
+		end := offset + fragmentSize
+		if end > len(data) {
+			end = len(data)
+		}
+
+		for offset < end {
+			readBytes, err := state.Receive(data, offset, end)
+			if err != nil {
+				return err
+			}
+
+			offset += readBytes
+		}
+	}
+
+	return nil
+}
+
+func TestVersionNegotiation(t *testing.T) {
+	parameter.NodeConfig.Set(database.CFG_BACKEND, database.BACKEND_MEMORY)
+	defer database.Close()
+
+	// both ends of the connection use the same identity, so they know each other as neighbors
+	neighbor := NewNeighbor(accountability.OwnId(), net.IPv4(127, 0, 0, 1), 0)
+	neighbors.Store(neighbor.GetIdentity().StringIdentifier, neighbor)
+	defer neighbors.Delete(neighbor.GetIdentity().StringIdentifier)
+
+	assert.Equal(t, negotiateVersion(t, neighbor), VERSION_2)
+
+	neighbor.setProtocolVersion(VERSION_1)
+	assert.Equal(t, negotiateVersion(t, neighbor), VERSION_1)
+
+	// a node that only speaks version 1 answers with its own version, so we fall back to version 1
+	neighbor.setProtocolVersion(0)
+	dialedProtocol, acceptedConn := connectProtocols(t, neighbor)
+	defer acceptedConn.Close()
+
+	closed := make(chan int)
+	dialedProtocol.Conn.Events.Close.Attach(events.NewClosure(func() {
+		close(closed)
+	}))
+	go dialedProtocol.Init()
+
+	if _, err := acceptedConn.Write([]byte{VERSION_1}); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the connection was not closed")
+	}
+	assert.Equal(t, neighbor.getProtocolVersion(), VERSION_1)
+}
+
+// connects two protocols over a local connection and returns the version that they agreed on
+func negotiateVersion(t *testing.T, neighbor *Neighbor) byte {
+	dialedProtocol, acceptedProtocol := completeHandshake(t, neighbor)
+	defer dialedProtocol.Conn.Close()
+	defer acceptedProtocol.Conn.Close()
+
+	assert.Equal(t, acceptedProtocol.Version, dialedProtocol.Version)
+
+	return dialedProtocol.Version
+}
+
+// connects two protocols over a local connection and waits until both completed the handshake
+func completeHandshake(t *testing.T, neighbor *Neighbor) (*protocol, *protocol) {
+	dialedProtocol, acceptedConn := connectProtocols(t, neighbor)
+	acceptedProtocol := newProtocol(network.NewManagedConnection(acceptedConn))
+
+	var handshakes sync.WaitGroup
+	handshakes.Add(2)
+	for _, protocol := range []*protocol{dialedProtocol, acceptedProtocol} {
+		protocol.Events.HandshakeCompleted.Attach(events.NewClosure(handshakes.Done))
+		protocol.Events.Error.Attach(events.NewClosure(func(err errors.IdentifiableError) {
+			t.Error(err)
+		}))
+
+		go protocol.Init()
+	}
+
+	completed := make(chan int)
+	go func() {
+		handshakes.Wait()
+		close(completed)
+	}()
+
+	select {
+	case <-completed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the handshake did not complete")
+	}
+
+	return dialedProtocol, acceptedProtocol
+}
+
+func connectProtocols(t *testing.T, neighbor *Neighbor) (*protocol, net.Conn) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	dialedConn, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	acceptedConn, err := listener.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dialedProtocol := newProtocol(network.NewManagedConnection(dialedConn))
+	dialedProtocol.dialedNeighbor = neighbor
+
+	return dialedProtocol, acceptedConn
+}
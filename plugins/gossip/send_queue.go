@@ -7,6 +7,7 @@ import (
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/iota.go/trinary"
 )
 
 // region plugin module setup //////////////////////////////////////////////////////////////////////////////////////////
@@ -37,17 +38,7 @@ func runSendQueue(plugin *node.Plugin) {
 				return
 
 			case tx := <-sendQueue:
-				connectedNeighborsMutex.RLock()
-				for _, neighborQueue := range neighborQueues {
-					select {
-					case neighborQueue.queue <- tx:
-						// log sth
-
-					default:
-						// log sth
-					}
-				}
-				connectedNeighborsMutex.RUnlock()
+				dispatchTransaction(tx)
 			}
 		}
 	})
@@ -63,19 +54,28 @@ func runSendQueue(plugin *node.Plugin) {
 
 // region public api ///////////////////////////////////////////////////////////////////////////////////////////////////
 
+// Sends the transaction to all neighbors except the ones that the transaction was received from.
 func SendTransaction(transaction *meta_transaction.MetaTransaction) {
 	sendQueue <- transaction
 }
 
 func (neighbor *Neighbor) SendTransaction(transaction *meta_transaction.MetaTransaction) {
-	if queue, exists := neighborQueues[neighbor.GetIdentity().StringIdentifier]; exists {
-		select {
-		case queue.queue <- transaction:
-			return
+	connectedNeighborsMutex.RLock()
+	queue, exists := neighborQueues[neighbor.GetIdentity().StringIdentifier]
+	connectedNeighborsMutex.RUnlock()
 
-		default:
-			return
-		}
+	if exists {
+		queue.enqueue(transaction)
+	}
+}
+
+func (neighbor *Neighbor) SendTransactionRequest(transactionHash trinary.Trytes) {
+	connectedNeighborsMutex.RLock()
+	queue, exists := neighborQueues[neighbor.GetIdentity().StringIdentifier]
+	connectedNeighborsMutex.RUnlock()
+
+	if exists {
+		queue.enqueueRequest(transactionHash)
 	}
 }
 
@@ -83,11 +83,39 @@ func (neighbor *Neighbor) SendTransaction(transaction *meta_transaction.MetaTran
 
 // region utility methods //////////////////////////////////////////////////////////////////////////////////////////////
 
+// queues the transaction for all connected neighbors except the ones that sent us the transaction
+func dispatchTransaction(transaction *meta_transaction.MetaTransaction) {
+	origins := getTransactionOrigins(transaction.GetHash())
+
+	connectedNeighborsMutex.RLock()
+	for identifier, neighborQueue := range neighborQueues {
+		if !origins.contains(identifier) {
+			neighborQueue.enqueue(transaction)
+		}
+	}
+	connectedNeighborsMutex.RUnlock()
+}
+
+// sends a request for the given transaction to all connected neighbors and returns the amount of contacted neighbors
+func broadcastTransactionRequest(transactionHash trinary.Trytes) (requestedNeighbors int) {
+	connectedNeighborsMutex.RLock()
+	for _, neighborQueue := range neighborQueues {
+		if neighborQueue.enqueueRequest(transactionHash) {
+			requestedNeighbors++
+		}
+	}
+	connectedNeighborsMutex.RUnlock()
+
+	return
+}
+
 func setupEventHandlers(neighbor *Neighbor) {
 	neighbor.Events.ProtocolConnectionEstablished.Attach(events.NewClosure(func(protocol *protocol) {
 		queue := &neighborQueue{
+			neighbor:       neighbor,
 			protocol:       protocol,
 			queue:          make(chan *meta_transaction.MetaTransaction, SEND_QUEUE_SIZE),
+			requestQueue:   make(chan trinary.Trytes, REQUEST_QUEUE_SIZE),
 			disconnectChan: make(chan int, 1),
 		}
 
@@ -120,9 +148,26 @@ func startNeighborSendQueue(neighbor *Neighbor, neighborQueue *neighborQueue) {
 				return
 
 			case tx := <-neighborQueue.queue:
+				var sent bool
 				switch neighborQueue.protocol.Version {
 				case VERSION_1:
-					sendTransactionV1(neighborQueue.protocol, tx)
+					sent = sendTransactionV1(neighborQueue.protocol, tx)
+				case VERSION_2:
+					sent = sendTransactionV2(neighborQueue.protocol, tx)
+				}
+
+				if sent {
+					neighbor.increaseSentTransactionsCount()
+
+					Events.SentTransaction.Trigger(neighbor, tx)
+				}
+
+			case transactionHash := <-neighborQueue.requestQueue:
+				switch neighborQueue.protocol.Version {
+				case VERSION_1:
+					sendTransactionRequestV1(neighborQueue.protocol, transactionHash)
+				case VERSION_2:
+					sendTransactionRequestV2(neighborQueue.protocol, transactionHash)
 				}
 			}
 		}
@@ -134,11 +179,38 @@ func startNeighborSendQueue(neighbor *Neighbor, neighborQueue *neighborQueue) {
 // region types and interfaces /////////////////////////////////////////////////////////////////////////////////////////
 
 type neighborQueue struct {
+	neighbor       *Neighbor
 	protocol       *protocol
 	queue          chan *meta_transaction.MetaTransaction
+	requestQueue   chan trinary.Trytes
 	disconnectChan chan int
 }
 
+// queues the transaction for the neighbor (the transaction is dropped if the queue is full)
+func (neighborQueue *neighborQueue) enqueue(transaction *meta_transaction.MetaTransaction) {
+	select {
+	case neighborQueue.queue <- transaction:
+	default:
+		neighborQueue.neighbor.increaseDroppedTransactionsCount()
+
+		Events.DroppedTransaction.Trigger(neighborQueue.neighbor, transaction)
+	}
+}
+
+// queues the request for the neighbor and returns false if it was dropped because the queue is full
+func (neighborQueue *neighborQueue) enqueueRequest(transactionHash trinary.Trytes) bool {
+	select {
+	case neighborQueue.requestQueue <- transactionHash:
+		return true
+	default:
+		neighborQueue.neighbor.increaseDroppedRequestsCount()
+
+		Events.DroppedTransactionRequest.Trigger(neighborQueue.neighbor, transactionHash)
+
+		return false
+	}
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region constants and variables //////////////////////////////////////////////////////////////////////////////////////
@@ -150,7 +222,8 @@ var connectedNeighborsMutex sync.RWMutex
 var sendQueue = make(chan *meta_transaction.MetaTransaction, SEND_QUEUE_SIZE)
 
 const (
-	SEND_QUEUE_SIZE = 500
+	SEND_QUEUE_SIZE    = 500
+	REQUEST_QUEUE_SIZE = 100
 )
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
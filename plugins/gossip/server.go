@@ -26,6 +26,7 @@ func configureServer(plugin *node.Plugin) {
 		// store protocol in neighbor if its a neighbor calling
 		protocol.Events.ReceiveIdentification.Attach(events.NewClosure(func(identity *identity.Identity) {
 			if protocol.Neighbor != nil {
+				protocol.Neighbor.trackConnection(protocol.Conn)
 
 				if protocol.Neighbor.GetAcceptedProtocol() == nil {
 					protocol.Neighbor.SetAcceptedProtocol(protocol)
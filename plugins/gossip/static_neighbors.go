@@ -0,0 +1,87 @@
+package gossip
+
+import (
+	"encoding/hex"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/goshimmer/packages/identity"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/parameter"
+)
+
+// region plugin module setup //////////////////////////////////////////////////////////////////////////////////////////
+
+func configureStaticNeighbors(plugin *node.Plugin) {
+	staticNeighbors = make([]*Neighbor, 0)
+
+	for _, neighborDefinition := range parameter.NodeConfig.GetStringSlice(GOSSIP_NEIGHBORS) {
+		if neighborDefinition == "" {
+			continue
+		}
+
+		neighbor, err := ParseNeighbor(neighborDefinition)
+		if err != nil {
+			log.Fatalf("invalid entry in the list of static neighbors: %s", err.Error())
+		}
+
+		staticNeighbors = append(staticNeighbors, neighbor)
+	}
+}
+
+// the neighbors are added once all plugins are configured, so every plugin gets notified about them
+func runStaticNeighbors(plugin *node.Plugin) {
+	for _, neighbor := range staticNeighbors {
+		AddNeighbor(neighbor)
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region public api ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Parses a neighbor definition of the form identity@host:port (where identity is the hex encoded identifier of the node)
+// and returns the corresponding static neighbor. Host names are resolved to their ip address when connecting.
+func ParseNeighbor(neighborDefinition string) (*Neighbor, errors.IdentifiableError) {
+	identityBits := strings.Split(neighborDefinition, "@")
+	if len(identityBits) != 2 {
+		return nil, ErrInvalidNeighborDefinition.Derive("missing identity in neighbor definition: " + neighborDefinition)
+	}
+
+	identifier, err := hex.DecodeString(identityBits[0])
+	if err != nil || len(identifier) != MARSHALED_IDENTITY_SIZE {
+		return nil, ErrInvalidNeighborDefinition.Derive("invalid identity in neighbor definition: " + neighborDefinition)
+	}
+
+	host, portString, err := net.SplitHostPort(identityBits[1])
+	if err != nil {
+		return nil, ErrInvalidNeighborDefinition.Derive("invalid address in neighbor definition: " + neighborDefinition + " (" + err.Error() + ")")
+	}
+
+	port, err := strconv.ParseUint(portString, 10, 16)
+	if err != nil || port == 0 {
+		return nil, ErrInvalidNeighborDefinition.Derive("invalid port in neighbor definition: " + neighborDefinition)
+	}
+
+	neighbor := NewStaticNeighbor(&identity.Identity{
+		Identifier:       identifier,
+		StringIdentifier: strings.ToLower(identityBits[0]),
+	}, net.ParseIP(host), uint16(port))
+
+	// host names are resolved before every connection attempt
+	if neighbor.GetAddress() == nil {
+		neighbor.SetHost(host)
+	}
+
+	return neighbor, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region constants and variables //////////////////////////////////////////////////////////////////////////////////////
+
+var staticNeighbors []*Neighbor
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,171 @@
+package gossip
+
+import (
+	"io"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/identity"
+	"github.com/iotaledger/goshimmer/packages/network"
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/parameter"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestParseNeighbor(t *testing.T) {
+	neighbor, err := ParseNeighbor("0123456789ABCDEF0123456789abcdef01234567@127.0.0.1:14666")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, neighbor.GetIdentity().StringIdentifier, "0123456789abcdef0123456789abcdef01234567")
+	assert.Equal(t, neighbor.GetAddress().String(), "127.0.0.1")
+	assert.Equal(t, neighbor.GetPort(), uint16(14666))
+	assert.Equal(t, neighbor.IsStatic(), true)
+
+	neighbor, err = ParseNeighbor("0123456789abcdef0123456789abcdef01234567@[::1]:14666")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, neighbor.GetAddress().String(), "::1")
+
+	// host names are resolved when connecting
+	neighbor, err = ParseNeighbor("0123456789abcdef0123456789abcdef01234567@localhost:14666")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, neighbor.GetHost(), "localhost")
+	if err := neighbor.resolveHost(); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, neighbor.GetAddress().IsLoopback(), true)
+
+	for _, invalidDefinition := range []string{
+		"127.0.0.1:14666",
+		"0123@127.0.0.1:14666",
+		"0123456789abcdef0123456789abcdef0123456x@127.0.0.1:14666",
+		"0123456789abcdef0123456789abcdef01234567@127.0.0.1",
+		"0123456789abcdef0123456789abcdef01234567@127.0.0.1:70000",
+	} {
+		_, err := ParseNeighbor(invalidDefinition)
+		assert.Equal(t, ErrInvalidNeighborDefinition.Equals(err), true)
+	}
+}
+
+func TestStaticNeighbor(t *testing.T) {
+	staticNeighbor, err := ParseNeighbor("0123456789abcdef0123456789abcdef01234567@127.0.0.1:14666")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AddNeighbor(staticNeighbor)
+	defer RemoveNeighbor(staticNeighbor.GetIdentity().StringIdentifier)
+
+	// the autopeering can not modify static neighbors
+	AddNeighbor(NewNeighbor(staticNeighbor.GetIdentity(), net.IPv4(127, 0, 0, 2), 14667))
+	assert.Equal(t, staticNeighbor.GetAddress().String(), "127.0.0.1")
+	assert.Equal(t, staticNeighbor.GetPort(), uint16(14666))
+
+	// but neighbors of the autopeering can be turned into static neighbors
+	neighbor := NewNeighbor(staticNeighbor.GetIdentity(), net.IPv4(127, 0, 0, 1), 14666)
+	RemoveNeighbor(staticNeighbor.GetIdentity().StringIdentifier)
+	AddNeighbor(neighbor)
+	AddNeighbor(staticNeighbor)
+	assert.Equal(t, neighbor.IsStatic(), true)
+
+	// static definitions keep the identity (and the public key) of the neighbors of the autopeering
+	autopeeringIdentity := identity.GenerateRandomIdentity()
+	autopeeringNeighbor := NewNeighbor(autopeeringIdentity, net.IPv4(127, 0, 0, 1), 14666)
+	AddNeighbor(autopeeringNeighbor)
+	defer RemoveNeighbor(autopeeringIdentity.StringIdentifier)
+
+	updatingNeighbor, err := ParseNeighbor(autopeeringIdentity.StringIdentifier + "@127.0.0.1:14667")
+	if err != nil {
+		t.Fatal(err)
+	}
+	AddNeighbor(updatingNeighbor)
+	assert.Equal(t, autopeeringNeighbor.GetIdentity(), autopeeringIdentity)
+	assert.Equal(t, autopeeringNeighbor.GetPort(), uint16(14667))
+	assert.Equal(t, autopeeringNeighbor.IsStatic(), true)
+
+	// the timeout between the connection attempts is capped
+	assert.Equal(t, getConnectionTimeout(1), CONNECTION_BASE_TIMEOUT)
+	assert.Equal(t, getConnectionTimeout(CONNECTION_MAX_ATTEMPTS), 16*CONNECTION_BASE_TIMEOUT)
+	assert.Equal(t, getConnectionTimeout(1000), CONNECTION_MAX_TIMEOUT)
+}
+
+func TestRemoveNeighbor(t *testing.T) {
+	neighbor, err := ParseNeighbor("0123456789abcdef0123456789abcdef01234567@127.0.0.1:14666")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	initiatedConn, initiatedRemoteConn := net.Pipe()
+	defer initiatedRemoteConn.Close()
+	acceptedConn, acceptedRemoteConn := net.Pipe()
+	defer acceptedRemoteConn.Close()
+
+	neighbor.SetInitiatedProtocol(newProtocol(network.NewManagedConnection(initiatedConn)))
+	neighbor.SetAcceptedProtocol(newProtocol(network.NewManagedConnection(acceptedConn)))
+
+	for _, remoteConn := range []net.Conn{initiatedRemoteConn, acceptedRemoteConn} {
+		if err := remoteConn.SetReadDeadline(time.Now().Add(time.Second)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	AddNeighbor(neighbor)
+	RemoveNeighbor(neighbor.GetIdentity().StringIdentifier)
+
+	// the remote ends notice that both connections were closed
+	for _, remoteConn := range []net.Conn{initiatedRemoteConn, acceptedRemoteConn} {
+		_, err := remoteConn.Read(make([]byte, 1))
+		assert.Equal(t, err, io.EOF)
+	}
+
+	_, exists := GetNeighbor(neighbor.GetIdentity().StringIdentifier)
+	assert.Equal(t, exists, false)
+}
+
+func TestStaticNeighborRejectingHandshake(t *testing.T) {
+	parameter.NodeConfig.Set(database.CFG_BACKEND, database.BACKEND_MEMORY)
+
+	// the neighbor accepts the tcp connections but closes them before the handshake
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	var acceptedConnections int32
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			atomic.AddInt32(&acceptedConnections, 1)
+			_ = conn.Close()
+		}
+	}()
+
+	staticNeighbor, err := ParseNeighbor("0123456789abcdef0123456789abcdef01234567@localhost:" + strconv.Itoa(listener.Addr().(*net.TCPAddr).Port))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AddNeighbor(staticNeighbor)
+	defer RemoveNeighbor(staticNeighbor.GetIdentity().StringIdentifier)
+
+	daemon.Start()
+	manageConnection(nil, staticNeighbor)
+	time.Sleep(500 * time.Millisecond)
+	daemon.ShutdownAndWait()
+
+	// the failed handshake is followed by the connection timeout instead of an immediate reconnect
+	assert.Equal(t, atomic.LoadInt32(&acceptedConnections), int32(1))
+}
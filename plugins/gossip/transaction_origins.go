@@ -0,0 +1,94 @@
+package gossip
+
+import (
+	"sync"
+
+	"github.com/iotaledger/goshimmer/packages/datastructure"
+	"github.com/iotaledger/goshimmer/packages/model/meta_transaction"
+	"github.com/iotaledger/iota.go/trinary"
+)
+
+// region public api ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Returns true if the transaction was received from the given neighbor (so the neighbor already knows it).
+func IsTransactionKnownBy(transaction *meta_transaction.MetaTransaction, neighbor *Neighbor) bool {
+	return getTransactionOrigins(transaction.GetHash()).contains(neighbor.GetIdentity().StringIdentifier)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utility methods //////////////////////////////////////////////////////////////////////////////////////////////
+
+// remembers that the neighbor sent us the accepted transaction (the neighbor is nil for transactions that were not
+// received through the gossip)
+func recordTransactionOrigin(transactionHash trinary.Trytes, neighbor *Neighbor) {
+	origins := transactionOriginsCache.ComputeIfAbsent(transactionHash, func() interface{} {
+		return &transactionOrigins{
+			identifiers: make(map[string]bool),
+		}
+	}).(*transactionOrigins)
+
+	if neighbor != nil && neighbor.GetIdentity() != nil {
+		origins.add(neighbor.GetIdentity().StringIdentifier)
+	}
+}
+
+// remembers that the neighbor sent us a duplicate (only if the transaction was accepted before, so invalid transactions
+// do not fill the cache)
+func recordDuplicateTransactionOrigin(transactionHash trinary.Trytes, neighbor *Neighbor) {
+	if neighbor == nil || neighbor.GetIdentity() == nil {
+		return
+	}
+
+	if origins := getTransactionOrigins(transactionHash); origins != nil {
+		origins.add(neighbor.GetIdentity().StringIdentifier)
+	}
+}
+
+// returns the neighbors that sent us the transaction (the result is nil if we do not know any of them)
+func getTransactionOrigins(transactionHash trinary.Trytes) *transactionOrigins {
+	if origins := transactionOriginsCache.Get(transactionHash); origins != nil {
+		return origins.(*transactionOrigins)
+	}
+
+	return nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region transactionOrigins ///////////////////////////////////////////////////////////////////////////////////////////
+
+type transactionOrigins struct {
+	identifiers map[string]bool
+	mutex       sync.RWMutex
+}
+
+func (origins *transactionOrigins) add(identifier string) {
+	origins.mutex.Lock()
+	origins.identifiers[identifier] = true
+	origins.mutex.Unlock()
+}
+
+func (origins *transactionOrigins) contains(identifier string) bool {
+	if origins == nil {
+		return false
+	}
+
+	origins.mutex.RLock()
+	defer origins.mutex.RUnlock()
+
+	return origins.identifiers[identifier]
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region constants and variables //////////////////////////////////////////////////////////////////////////////////////
+
+// the origins are kept until the transaction had enough time to get solid and forwarded
+var transactionOriginsCache = datastructure.NewLRUCache(TRANSACTION_ORIGINS_CACHE_SIZE)
+
+const (
+	TRANSACTION_ORIGINS_CACHE_SIZE = 5000
+)
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1,16 +1,91 @@
 package gossip
 
 import (
+	"sync/atomic"
+
 	"github.com/iotaledger/goshimmer/packages/filter"
 	"github.com/iotaledger/goshimmer/packages/model/meta_transaction"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/parameter"
 )
 
+// region plugin module setup //////////////////////////////////////////////////////////////////////////////////////////
+
+func configureTransactionProcessor(plugin *node.Plugin) {
+	SetMinWeightMagnitude(parameter.NodeConfig.GetInt(GOSSIP_MIN_WEIGHT_MAGNITUDE))
+
+	Events.InvalidTransaction.Attach(events.NewClosure(func(neighbor *Neighbor, transaction *meta_transaction.MetaTransaction) {
+		log.Debugf("dropped transaction %s with insufficient weight magnitude %d from %s", transaction.GetHash(), transaction.GetWeightMagnitude(), neighborIdentifier(neighbor))
+	}))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region public api ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-func ProcessReceivedTransactionData(transactionData []byte) {
-	if transactionFilter.Add(transactionData) {
-		Events.ReceiveTransaction.Trigger(meta_transaction.FromBytes(transactionData))
+// Processes the transaction data that was received from the given neighbor. Transactions with a weight magnitude below
+// the configured minimum are dropped before they are processed any further and transactions that were seen recently are
+// ignored. The weight magnitude is checked first, so only valid transactions enter the duplicate filter and invalid
+// transactions are counted as invalid every time they are received. The neighbor is remembered as an origin of the
+// accepted transactions (and of their duplicates), so the transaction is not gossiped back.
+func ProcessReceivedTransactionData(transactionData []byte, neighbor *Neighbor) {
+	if neighbor != nil {
+		neighbor.increaseReceivedTransactionsCount()
+	}
+
+	transaction := meta_transaction.FromBytes(transactionData)
+	if transaction.GetWeightMagnitude() < GetMinWeightMagnitude() {
+		if neighbor != nil {
+			neighbor.increaseInvalidTransactionsCount()
+		}
+
+		Events.InvalidTransaction.Trigger(neighbor, transaction)
+
+		return
+	}
+
+	if !transactionFilter.Add(transactionData) {
+		if neighbor != nil {
+			neighbor.increaseDuplicateTransactionsCount()
+		}
+
+		// the neighbor knows the transaction, so we do not have to send it back
+		recordDuplicateTransactionOrigin(transaction.GetHash(), neighbor)
+
+		Events.DuplicateTransaction.Trigger(neighbor)
+
+		return
 	}
+
+	recordTransactionOrigin(transaction.GetHash(), neighbor)
+
+	if neighbor != nil {
+		neighbor.increaseNewTransactionsCount()
+	}
+
+	Events.NewTransaction.Trigger(neighbor, transaction)
+	Events.ReceiveTransaction.Trigger(transaction)
+}
+
+func GetMinWeightMagnitude() int {
+	return int(atomic.LoadInt32(&minWeightMagnitude))
+}
+
+func SetMinWeightMagnitude(weightMagnitude int) {
+	atomic.StoreInt32(&minWeightMagnitude, int32(weightMagnitude))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utility methods //////////////////////////////////////////////////////////////////////////////////////////////
+
+func neighborIdentifier(neighbor *Neighbor) string {
+	if neighbor == nil || neighbor.GetIdentity() == nil {
+		return "unknown neighbor"
+	}
+
+	return neighbor.GetIdentity().StringIdentifier
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -19,6 +94,8 @@ func ProcessReceivedTransactionData(transactionData []byte) {
 
 var transactionFilter = filter.NewByteArrayFilter(TRANSACTION_FILTER_SIZE)
 
+var minWeightMagnitude int32
+
 const (
 	TRANSACTION_FILTER_SIZE = 500
 )
@@ -4,17 +4,72 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/iotaledger/goshimmer/packages/identity"
 	"github.com/iotaledger/goshimmer/packages/model/meta_transaction"
+	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/iota.go/consts"
+	"github.com/magiconair/properties/assert"
 )
 
+func TestProcessReceivedTransactionData(t *testing.T) {
+	defer SetMinWeightMagnitude(0)
+
+	receivedTransactions := 0
+	onReceiveTransaction := events.NewClosure(func(transaction *meta_transaction.MetaTransaction) {
+		receivedTransactions++
+	})
+	Events.ReceiveTransaction.Attach(onReceiveTransaction)
+	defer Events.ReceiveTransaction.Detach(onReceiveTransaction)
+
+	invalidTransactions := 0
+	onInvalidTransaction := events.NewClosure(func(neighbor *Neighbor, transaction *meta_transaction.MetaTransaction) {
+		invalidTransactions++
+	})
+	Events.InvalidTransaction.Attach(onInvalidTransaction)
+	defer Events.InvalidTransaction.Detach(onInvalidTransaction)
+
+	neighbor := NewNeighbor(nil, nil, 0)
+
+	// no hash has that many trailing zero trits, so the transaction is dropped
+	SetMinWeightMagnitude(consts.HashTrinarySize + 1)
+	invalidTransaction := setupTransaction(meta_transaction.MARSHALED_TOTAL_SIZE / consts.NumberOfTritsInAByte)
+	invalidTransaction[0] = 1
+	ProcessReceivedTransactionData(invalidTransaction, neighbor)
+
+	// invalid transactions do not enter the duplicate filter, so they are counted as invalid again
+	ProcessReceivedTransactionData(invalidTransaction, neighbor)
+
+	assert.Equal(t, receivedTransactions, 0)
+	assert.Equal(t, invalidTransactions, 2)
+	assert.Equal(t, neighbor.GetInvalidTransactionsCount(), uint64(2))
+	assert.Equal(t, transactionFilter.Contains(invalidTransaction), false)
+
+	SetMinWeightMagnitude(0)
+	validTransaction := setupTransaction(meta_transaction.MARSHALED_TOTAL_SIZE / consts.NumberOfTritsInAByte)
+	validTransaction[0] = 2
+	ProcessReceivedTransactionData(validTransaction, neighbor)
+
+	// duplicates are ignored
+	ProcessReceivedTransactionData(validTransaction, neighbor)
+
+	assert.Equal(t, receivedTransactions, 1)
+	assert.Equal(t, invalidTransactions, 2)
+
+	// all received transactions are attributed to the neighbor
+	statistics := neighbor.GetStatistics()
+	assert.Equal(t, statistics.ReceivedTransactions, uint64(4))
+	assert.Equal(t, statistics.NewTransactions, uint64(1))
+	assert.Equal(t, statistics.DuplicateTransactions, uint64(1))
+	assert.Equal(t, statistics.InvalidTransactions, uint64(2))
+}
+
 func BenchmarkProcessSimilarTransactionsFiltered(b *testing.B) {
 	byteArray := setupTransaction(meta_transaction.MARSHALED_TOTAL_SIZE / consts.NumberOfTritsInAByte)
 
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
-		ProcessReceivedTransactionData(byteArray)
+		ProcessReceivedTransactionData(byteArray, nil)
 	}
 }
 
@@ -47,3 +102,68 @@ func setupTransaction(byteArraySize int) []byte {
 
 	return byteArray
 }
+
+func TestTransactionOrigins(t *testing.T) {
+	origin := NewNeighbor(identity.GenerateRandomIdentity(), nil, 0)
+	otherNeighbor := NewNeighbor(identity.GenerateRandomIdentity(), nil, 0)
+
+	transactionData := setupTransaction(meta_transaction.MARSHALED_TOTAL_SIZE / consts.NumberOfTritsInAByte)
+	transactionData[0] = 3
+	ProcessReceivedTransactionData(transactionData, origin)
+
+	transaction := meta_transaction.FromBytes(transactionData)
+	assert.Equal(t, IsTransactionKnownBy(transaction, origin), true)
+	assert.Equal(t, IsTransactionKnownBy(transaction, otherNeighbor), false)
+
+	// neighbors that send us a duplicate know the transaction as well
+	ProcessReceivedTransactionData(transactionData, otherNeighbor)
+	assert.Equal(t, IsTransactionKnownBy(transaction, otherNeighbor), true)
+
+	// transactions with an insufficient weight magnitude are not recorded
+	defer SetMinWeightMagnitude(0)
+	SetMinWeightMagnitude(consts.HashTrinarySize + 1)
+
+	invalidTransactionData := setupTransaction(meta_transaction.MARSHALED_TOTAL_SIZE / consts.NumberOfTritsInAByte)
+	invalidTransactionData[0] = 4
+	ProcessReceivedTransactionData(invalidTransactionData, origin)
+	ProcessReceivedTransactionData(invalidTransactionData, otherNeighbor)
+
+	invalidTransaction := meta_transaction.FromBytes(invalidTransactionData)
+	assert.Equal(t, IsTransactionKnownBy(invalidTransaction, origin), false)
+	assert.Equal(t, IsTransactionKnownBy(invalidTransaction, otherNeighbor), false)
+}
+
+func TestSendTransactionSkipsOrigins(t *testing.T) {
+	origin := NewNeighbor(identity.GenerateRandomIdentity(), nil, 0)
+	otherNeighbor := NewNeighbor(identity.GenerateRandomIdentity(), nil, 0)
+
+	queues := make(map[*Neighbor]*neighborQueue)
+	for _, neighbor := range []*Neighbor{origin, otherNeighbor} {
+		queues[neighbor] = &neighborQueue{
+			neighbor: neighbor,
+			queue:    make(chan *meta_transaction.MetaTransaction, SEND_QUEUE_SIZE),
+		}
+
+		connectedNeighborsMutex.Lock()
+		neighborQueues[neighbor.GetIdentity().StringIdentifier] = queues[neighbor]
+		connectedNeighborsMutex.Unlock()
+	}
+	defer func() {
+		connectedNeighborsMutex.Lock()
+		delete(neighborQueues, origin.GetIdentity().StringIdentifier)
+		delete(neighborQueues, otherNeighbor.GetIdentity().StringIdentifier)
+		connectedNeighborsMutex.Unlock()
+	}()
+
+	transactionData := setupTransaction(meta_transaction.MARSHALED_TOTAL_SIZE / consts.NumberOfTritsInAByte)
+	transactionData[0] = 5
+	ProcessReceivedTransactionData(transactionData, origin)
+
+	// the transaction is forwarded to every neighbor except the one that sent it
+	SendTransaction(meta_transaction.FromBytes(transactionData))
+	dispatchTransaction(<-sendQueue)
+
+	assert.Equal(t, len(queues[origin].queue), 0)
+	assert.Equal(t, len(queues[otherNeighbor].queue), 1)
+	assert.Equal(t, (<-queues[otherNeighbor].queue).GetHash(), meta_transaction.FromBytes(transactionData).GetHash())
+}
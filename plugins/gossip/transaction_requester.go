@@ -0,0 +1,146 @@
+package gossip
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/model/meta_transaction"
+	"github.com/iotaledger/goshimmer/packages/timeutil"
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/iota.go/trinary"
+)
+
+// region plugin module setup //////////////////////////////////////////////////////////////////////////////////////////
+
+func configureTransactionRequester(plugin *node.Plugin) {
+	Events.ReceiveTransaction.Attach(events.NewClosure(func(transaction *meta_transaction.MetaTransaction) {
+		pendingRequestsMutex.Lock()
+		delete(pendingRequests, transaction.GetHash())
+		pendingRequestsMutex.Unlock()
+	}))
+
+	Events.TransactionRequestFailed.Attach(events.NewClosure(func(transactionHash trinary.Trytes) {
+		log.Warningf("giving up on missing transaction %s after %d requests", transactionHash, TRANSACTION_REQUEST_MAX_ATTEMPTS)
+	}))
+
+	daemon.Events.Shutdown.Attach(events.NewClosure(func() {
+		log.Info("Stopping Transaction Requester ...")
+	}))
+}
+
+func runTransactionRequester(plugin *node.Plugin) {
+	log.Info("Starting Transaction Requester ...")
+
+	daemon.BackgroundWorker("Gossip Transaction Requester", func() {
+		log.Info("Starting Transaction Requester ... done")
+
+		timeutil.Ticker(processPendingRequests, TRANSACTION_REQUEST_INTERVAL)
+
+		log.Info("Stopping Transaction Requester ... done")
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region public api ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Schedules a request for the given transaction. The request is repeated until the transaction is received or the
+// maximum amount of attempts is reached.
+func RequestTransaction(transactionHash trinary.Trytes) {
+	pendingRequestsMutex.Lock()
+	if _, exists := pendingRequests[transactionHash]; !exists {
+		pendingRequests[transactionHash] = &transactionRequest{}
+	}
+	pendingRequestsMutex.Unlock()
+}
+
+func IsTransactionRequested(transactionHash trinary.Trytes) (result bool) {
+	pendingRequestsMutex.RLock()
+	_, result = pendingRequests[transactionHash]
+	pendingRequestsMutex.RUnlock()
+
+	return
+}
+
+func GetPendingTransactionRequestsCount() (result int) {
+	pendingRequestsMutex.RLock()
+	result = len(pendingRequests)
+	pendingRequestsMutex.RUnlock()
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utility methods //////////////////////////////////////////////////////////////////////////////////////////////
+
+// sends the requests that timed out again and removes the ones that exceeded the maximum amount of attempts
+func processPendingRequests() {
+	requestsToSend := make([]trinary.Trytes, 0)
+	failedRequests := make([]trinary.Trytes, 0)
+
+	pendingRequestsMutex.Lock()
+	for transactionHash, request := range pendingRequests {
+		if time.Since(request.lastAttempt) < TRANSACTION_REQUEST_TIMEOUT {
+			continue
+		}
+
+		if request.attempts >= TRANSACTION_REQUEST_MAX_ATTEMPTS {
+			delete(pendingRequests, transactionHash)
+
+			failedRequests = append(failedRequests, transactionHash)
+
+			continue
+		}
+
+		requestsToSend = append(requestsToSend, transactionHash)
+	}
+	pendingRequestsMutex.Unlock()
+
+	for _, transactionHash := range requestsToSend {
+		// only count the attempt if at least one neighbor was asked
+		if broadcastTransactionRequest(transactionHash) == 0 {
+			continue
+		}
+
+		pendingRequestsMutex.Lock()
+		if request, exists := pendingRequests[transactionHash]; exists {
+			request.attempts++
+			request.lastAttempt = time.Now()
+		}
+		pendingRequestsMutex.Unlock()
+
+		Events.SendTransactionRequest.Trigger(transactionHash)
+	}
+
+	for _, transactionHash := range failedRequests {
+		Events.TransactionRequestFailed.Trigger(transactionHash)
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region types and interfaces /////////////////////////////////////////////////////////////////////////////////////////
+
+type transactionRequest struct {
+	attempts    int
+	lastAttempt time.Time
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region constants and variables //////////////////////////////////////////////////////////////////////////////////////
+
+var pendingRequests = make(map[trinary.Trytes]*transactionRequest)
+
+var pendingRequestsMutex sync.RWMutex
+
+const (
+	TRANSACTION_REQUEST_INTERVAL     = 1 * time.Second
+	TRANSACTION_REQUEST_TIMEOUT      = 5 * time.Second
+	TRANSACTION_REQUEST_MAX_ATTEMPTS = 10
+)
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,61 @@
+package gossip
+
+import (
+	"testing"
+
+	"github.com/iotaledger/goshimmer/packages/identity"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/iota.go/trinary"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestTransactionRequester(t *testing.T) {
+	neighbor := NewNeighbor(identity.GenerateRandomIdentity(), nil, 0)
+	queue := &neighborQueue{
+		neighbor:     neighbor,
+		requestQueue: make(chan trinary.Trytes, REQUEST_QUEUE_SIZE),
+	}
+
+	connectedNeighborsMutex.Lock()
+	neighborQueues[neighbor.GetIdentity().StringIdentifier] = queue
+	connectedNeighborsMutex.Unlock()
+	defer func() {
+		connectedNeighborsMutex.Lock()
+		delete(neighborQueues, neighbor.GetIdentity().StringIdentifier)
+		connectedNeighborsMutex.Unlock()
+	}()
+
+	transactionHash := trinary.Trytes("REQUESTED9TRANSACTION999999999999999999999999999999999999999999999999999999999999")
+	RequestTransaction(transactionHash)
+	assert.Equal(t, IsTransactionRequested(transactionHash), true)
+
+	// the request is sent to every connected neighbor
+	processPendingRequests()
+	assert.Equal(t, len(queue.requestQueue), 1)
+	assert.Equal(t, <-queue.requestQueue, transactionHash)
+
+	// requests are not repeated before they timed out
+	processPendingRequests()
+	assert.Equal(t, len(queue.requestQueue), 0)
+
+	pendingRequestsMutex.Lock()
+	delete(pendingRequests, transactionHash)
+	pendingRequestsMutex.Unlock()
+
+	// requests that do not fit into the queue of a neighbor are counted as dropped
+	var droppedRequests int
+	droppedClosure := events.NewClosure(func(droppingNeighbor *Neighbor, droppedHash trinary.Trytes) {
+		if droppingNeighbor == neighbor && droppedHash == transactionHash {
+			droppedRequests++
+		}
+	})
+	Events.DroppedTransactionRequest.Attach(droppedClosure)
+	defer Events.DroppedTransactionRequest.Detach(droppedClosure)
+
+	for i := 0; i < REQUEST_QUEUE_SIZE; i++ {
+		assert.Equal(t, broadcastTransactionRequest(transactionHash), 1)
+	}
+	assert.Equal(t, broadcastTransactionRequest(transactionHash), 0)
+	assert.Equal(t, droppedRequests, 1)
+	assert.Equal(t, neighbor.GetStatistics().DroppedRequests, uint64(1))
+}
@@ -0,0 +1,64 @@
+package ledger
+
+import (
+	"encoding/binary"
+
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/goshimmer/packages/typeutils"
+	"github.com/iotaledger/iota.go/trinary"
+)
+
+// balanceDiff contains the balance changes that a bundle causes per address.
+type balanceDiff map[trinary.Trytes]int64
+
+func (diff balanceDiff) Invert() balanceDiff {
+	result := make(balanceDiff, len(diff))
+	for address, delta := range diff {
+		result[address] = -delta
+	}
+
+	return result
+}
+
+// region marshaling functions /////////////////////////////////////////////////////////////////////////////////////////
+
+func (diff balanceDiff) Marshal() []byte {
+	result := make([]byte, len(diff)*BALANCE_DIFF_ENTRY_SIZE)
+
+	offset := 0
+	for address, delta := range diff {
+		copy(result[offset:offset+ADDRESS_SIZE], typeutils.StringToBytes(address))
+		binary.BigEndian.PutUint64(result[offset+ADDRESS_SIZE:offset+BALANCE_DIFF_ENTRY_SIZE], uint64(delta))
+
+		offset += BALANCE_DIFF_ENTRY_SIZE
+	}
+
+	return result
+}
+
+func (diff *balanceDiff) Unmarshal(data []byte) errors.IdentifiableError {
+	if len(data)%BALANCE_DIFF_ENTRY_SIZE != 0 {
+		return ErrUnmarshalFailed.Derive(errors.New("unmarshall failed"), "marshaled balance diff has an invalid size")
+	}
+
+	*diff = make(balanceDiff, len(data)/BALANCE_DIFF_ENTRY_SIZE)
+	for offset := 0; offset < len(data); offset += BALANCE_DIFF_ENTRY_SIZE {
+		address := trinary.Trytes(string(data[offset : offset+ADDRESS_SIZE]))
+
+		(*diff)[address] = int64(binary.BigEndian.Uint64(data[offset+ADDRESS_SIZE : offset+BALANCE_DIFF_ENTRY_SIZE]))
+	}
+
+	return nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region constants and variables //////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	ADDRESS_SIZE            = 81
+	BALANCE_SIZE            = 8
+	BALANCE_DIFF_ENTRY_SIZE = ADDRESS_SIZE + BALANCE_SIZE
+)
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,11 @@
+package ledger
+
+import "github.com/iotaledger/goshimmer/packages/errors"
+
+var (
+	ErrDatabaseError   = errors.Wrap(errors.New("database error"), "failed to access the database")
+	ErrUnmarshalFailed = errors.Wrap(errors.New("unmarshall failed"), "input data is corrupted")
+	ErrInvalidBundle   = errors.Wrap(errors.New("invalid bundle"), "the values of the bundle do not sum up to zero")
+	ErrOverspend       = errors.Wrap(errors.New("overspend"), "the bundle spends more than the balance of an input")
+	ErrBalanceOverflow = errors.Wrap(errors.New("balance overflow"), "the balance exceeds the supported range")
+)
@@ -0,0 +1,27 @@
+package ledger
+
+import (
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/goshimmer/packages/model/bundle"
+	"github.com/iotaledger/hive.go/events"
+)
+
+var Events = pluginEvents{
+	BundleApplied:    events.NewEvent(bundleCaller),
+	BundleRejected:   events.NewEvent(bundleErrorCaller),
+	BundleRolledBack: events.NewEvent(bundleCaller),
+}
+
+type pluginEvents struct {
+	BundleApplied    *events.Event
+	BundleRejected   *events.Event
+	BundleRolledBack *events.Event
+}
+
+func bundleCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*bundle.Bundle))(params[0].(*bundle.Bundle))
+}
+
+func bundleErrorCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*bundle.Bundle, errors.IdentifiableError))(params[0].(*bundle.Bundle), params[1].(errors.IdentifiableError))
+}
@@ -0,0 +1,334 @@
+package ledger
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/goshimmer/packages/model/bundle"
+	"github.com/iotaledger/goshimmer/packages/model/value_transaction"
+	"github.com/iotaledger/goshimmer/packages/typeutils"
+	"github.com/iotaledger/goshimmer/plugins/tangle"
+	"github.com/iotaledger/iota.go/trinary"
+)
+
+// region public api ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+func GetBalance(address trinary.Trytes) (int64, errors.IdentifiableError) {
+	databaseInit.Do(initDatabases)
+
+	ledgerMutex.RLock()
+	defer ledgerMutex.RUnlock()
+
+	return getBalanceFromDatabase(address)
+}
+
+// Returns the balances of all addresses that hold funds.
+func GetBalances() (result map[trinary.Trytes]int64, err errors.IdentifiableError) {
+	databaseInit.Do(initDatabases)
+
+	ledgerMutex.RLock()
+	defer ledgerMutex.RUnlock()
+
+	result = make(map[trinary.Trytes]int64)
+	if dbErr := balancesDatabase.ForEach(func(key []byte, value []byte) {
+		result[trinary.Trytes(string(key))] = int64(binary.BigEndian.Uint64(value))
+	}); dbErr != nil {
+		err = ErrDatabaseError.Derive(dbErr, "failed to iterate over the balances")
+	}
+
+	return
+}
+
+// Replaces the ledger state with the given balances (i.e. the balances of an imported snapshot). It can be called before
+// the plugin is configured.
+func SetBalances(balances map[trinary.Trytes]int64) errors.IdentifiableError {
+	databaseInit.Do(initDatabases)
+
+	ledgerMutex.Lock()
+	defer ledgerMutex.Unlock()
+
+	for _, db := range []database.Database{balancesDatabase, appliedBundlesDatabase} {
+		if err := clearDatabase(db); err != nil {
+			return err
+		}
+	}
+
+	batch := database.NewBatch()
+	for address, balance := range balances {
+		if err := storeBalanceInBatch(batch, address, balance); err != nil {
+			return err
+		}
+	}
+
+	if err := batch.Commit(); err != nil {
+		return ErrDatabaseError.Derive(err, "failed to store the balances")
+	}
+
+	return nil
+}
+
+// Applies the value transfers of a solid value bundle to the balances. The signatures of the bundle have to be validated
+// by the bundleprocessor before (only bundles with valid signatures are marked as solid). The bundle is rejected if its
+// values do not sum up to zero, if it spends more than the balance of one of its inputs or if a balance would exceed the
+// supported range.
+func ApplyBundle(valueBundle *bundle.Bundle, bundleTransactions []*value_transaction.ValueTransaction) (err errors.IdentifiableError) {
+	databaseInit.Do(initDatabases)
+
+	defer func() {
+		if err != nil {
+			Events.BundleRejected.Trigger(valueBundle, err)
+		}
+	}()
+
+	diff := make(balanceDiff)
+	var sum int64
+	for _, transaction := range bundleTransactions {
+		if value := transaction.GetValue(); value != 0 {
+			addressDelta, addressOverflow := addValues(diff[transaction.GetAddress()], value)
+			newSum, sumOverflow := addValues(sum, value)
+			if addressOverflow || sumOverflow {
+				return ErrBalanceOverflow.Derive(errors.New("balance overflow"), "the values of bundle "+valueBundle.GetHash()+" exceed the supported range")
+			}
+
+			diff[transaction.GetAddress()] = addressDelta
+			sum = newSum
+		}
+	}
+	if sum != 0 {
+		return ErrInvalidBundle.Derive(errors.New("invalid bundle"), "bundle "+valueBundle.GetHash()+" creates or destroys tokens")
+	}
+
+	ledgerMutex.Lock()
+	defer ledgerMutex.Unlock()
+
+	if applied, containsErr := appliedBundlesDatabase.Contains(typeutils.StringToBytes(valueBundle.GetHash())); containsErr != nil {
+		return ErrDatabaseError.Derive(containsErr, "failed to check if the bundle was applied")
+	} else if applied {
+		return nil
+	}
+
+	// the new balances and the applied bundle are written together, so a crash can not apply a bundle twice
+	batch := database.NewBatch()
+	if err = applyDiff(batch, diff); err != nil {
+		return
+	}
+
+	if dbErr := batch.Set(appliedBundlesDatabase, typeutils.StringToBytes(valueBundle.GetHash()), diff.Marshal()); dbErr != nil {
+		return ErrDatabaseError.Derive(dbErr, "failed to store the applied bundle")
+	}
+
+	if dbErr := batch.Commit(); dbErr != nil {
+		return ErrDatabaseError.Derive(dbErr, "failed to apply the bundle")
+	}
+
+	Events.BundleApplied.Trigger(valueBundle)
+
+	return nil
+}
+
+// Reverts the changes of an applied bundle (i.e. if it belongs to a branch that lost a conflict).
+func RollbackBundle(valueBundle *bundle.Bundle) errors.IdentifiableError {
+	databaseInit.Do(initDatabases)
+
+	ledgerMutex.Lock()
+	defer ledgerMutex.Unlock()
+
+	marshaledDiff, err := appliedBundlesDatabase.Get(typeutils.StringToBytes(valueBundle.GetHash()))
+	if err == database.ErrKeyNotFound {
+		return nil
+	} else if err != nil {
+		return ErrDatabaseError.Derive(err, "failed to retrieve the applied bundle")
+	}
+
+	var diff balanceDiff
+	if err := diff.Unmarshal(marshaledDiff); err != nil {
+		return err
+	}
+
+	batch := database.NewBatch()
+	if err := applyDiff(batch, diff.Invert()); err != nil {
+		return err
+	}
+
+	if err := batch.Delete(appliedBundlesDatabase, typeutils.StringToBytes(valueBundle.GetHash())); err != nil {
+		return ErrDatabaseError.Derive(err, "failed to remove the applied bundle")
+	}
+
+	if err := batch.Commit(); err != nil {
+		return ErrDatabaseError.Derive(err, "failed to roll back the bundle")
+	}
+
+	Events.BundleRolledBack.Trigger(valueBundle)
+
+	return nil
+}
+
+// Reverts the given bundle and all applied bundles in its future cone (the bundles that might depend on its funds). The
+// bundles are rolled back in the reverse order of their distance to the given bundle.
+func RollbackBranch(valueBundle *bundle.Bundle) errors.IdentifiableError {
+	branchBundles := []*bundle.Bundle{valueBundle}
+
+	walker := tangle.NewFutureConeWalker(valueBundle.GetHash())
+	for walker.Next() {
+		if transaction := walker.Transaction(); transaction.IsHead() {
+			if applied, err := IsBundleApplied(transaction.GetHash()); err != nil {
+				return err
+			} else if !applied {
+				continue
+			}
+
+			if cachedBranchBundle, err := tangle.GetBundle(transaction.GetHash()); err != nil {
+				return err
+			} else if cachedBranchBundle != nil {
+				branchBundles = append(branchBundles, cachedBranchBundle.Unwrap())
+				cachedBranchBundle.Release()
+			}
+		}
+	}
+	if err := walker.Err(); err != nil {
+		return err
+	}
+
+	for i := len(branchBundles) - 1; i >= 0; i-- {
+		if err := RollbackBundle(branchBundles[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func IsBundleApplied(headTransactionHash trinary.Trytes) (bool, errors.IdentifiableError) {
+	databaseInit.Do(initDatabases)
+
+	if applied, err := appliedBundlesDatabase.Contains(typeutils.StringToBytes(headTransactionHash)); err != nil {
+		return false, ErrDatabaseError.Derive(err, "failed to check if the bundle was applied")
+	} else {
+		return applied, nil
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utility methods //////////////////////////////////////////////////////////////////////////////////////////////
+
+// checks that no balance becomes negative or overflows and adds the new balances to the batch (has to be called while
+// holding the ledgerMutex)
+func applyDiff(batch database.Batch, diff balanceDiff) errors.IdentifiableError {
+	newBalances := make(map[trinary.Trytes]int64, len(diff))
+	for address, delta := range diff {
+		balance, err := getBalanceFromDatabase(address)
+		if err != nil {
+			return err
+		}
+
+		newBalance, overflow := addValues(balance, delta)
+		if overflow {
+			return ErrBalanceOverflow.Derive(errors.New("balance overflow"), "the balance of address "+address+" exceeds the supported range")
+		} else if newBalance < 0 {
+			return ErrOverspend.Derive(errors.New("overspend"), "address "+address+" does not hold enough funds")
+		}
+
+		newBalances[address] = newBalance
+	}
+
+	for address, balance := range newBalances {
+		if err := storeBalanceInBatch(batch, address, balance); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// returns the sum of both values and if it overflows
+func addValues(a int64, b int64) (int64, bool) {
+	sum := a + b
+
+	return sum, (b > 0 && sum < a) || (b < 0 && sum > a)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region database /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var balancesDatabase database.Database
+
+// contains the balance changes of the applied bundles, so they can be rolled back
+var appliedBundlesDatabase database.Database
+
+var databaseInit sync.Once
+
+func initDatabases() {
+	if db, err := database.Get("ledgerBalances"); err != nil {
+		panic(err)
+	} else {
+		balancesDatabase = db
+	}
+
+	if db, err := database.Get("ledgerAppliedBundles"); err != nil {
+		panic(err)
+	} else {
+		appliedBundlesDatabase = db
+	}
+}
+
+func getBalanceFromDatabase(address trinary.Trytes) (int64, errors.IdentifiableError) {
+	balanceBytes, err := balancesDatabase.Get(typeutils.StringToBytes(address))
+	if err == database.ErrKeyNotFound {
+		return 0, nil
+	} else if err != nil {
+		return 0, ErrDatabaseError.Derive(err, "failed to retrieve balance")
+	}
+
+	return int64(binary.BigEndian.Uint64(balanceBytes)), nil
+}
+
+// stores the balance or removes the address if it is empty
+func storeBalanceInBatch(batch database.Batch, address trinary.Trytes, balance int64) errors.IdentifiableError {
+	if balance == 0 {
+		if err := batch.Delete(balancesDatabase, typeutils.StringToBytes(address)); err != nil {
+			return ErrDatabaseError.Derive(err, "failed to remove balance")
+		}
+
+		return nil
+	}
+
+	balanceBytes := make([]byte, BALANCE_SIZE)
+	binary.BigEndian.PutUint64(balanceBytes, uint64(balance))
+
+	if err := batch.Set(balancesDatabase, typeutils.StringToBytes(address), balanceBytes); err != nil {
+		return ErrDatabaseError.Derive(err, "failed to store balance")
+	}
+
+	return nil
+}
+
+func clearDatabase(db database.Database) errors.IdentifiableError {
+	keys := make([][]byte, 0)
+	if _, err := db.Iterate(func(key []byte, value []byte) bool {
+		keys = append(keys, append([]byte{}, key...))
+
+		return true
+	}, database.KeysOnly()); err != nil {
+		return ErrDatabaseError.Derive(err, "failed to iterate over the ledger state")
+	}
+
+	for _, key := range keys {
+		if err := db.Delete(key); err != nil {
+			return ErrDatabaseError.Derive(err, "failed to clear the ledger state")
+		}
+	}
+
+	return nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region constants and variables //////////////////////////////////////////////////////////////////////////////////////
+
+var ledgerMutex sync.RWMutex
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
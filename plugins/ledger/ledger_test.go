@@ -0,0 +1,113 @@
+package ledger
+
+import (
+	"math"
+	"os"
+	"testing"
+
+	"github.com/iotaledger/goshimmer/packages/client"
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/model/bundle"
+	"github.com/iotaledger/goshimmer/packages/model/meta_transaction"
+	"github.com/iotaledger/goshimmer/packages/model/value_transaction"
+	"github.com/iotaledger/hive.go/parameter"
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/trinary"
+	"github.com/magiconair/properties/assert"
+)
+
+var seed = client.NewSeed("YFHQWAUPCXC9S9DSHP9NDF9RLNPMZVCMSJKUKQP9SWUSUCPRQXCMDVDVZ9SHHESHIQNCXWBJF9UJSWE9Z", consts.SecurityLevelMedium)
+
+func TestMain(m *testing.M) {
+	parameter.FetchConfig(false)
+	parameter.NodeConfig.Set(database.CFG_BACKEND, database.BACKEND_MEMORY)
+	os.Exit(m.Run())
+}
+
+func TestLedger(t *testing.T) {
+	inputAddress := seed.GetAddress(0).GetTrytes()
+	outputAddress := seed.GetAddress(1).GetTrytes()
+
+	if err := SetBalances(map[trinary.Trytes]int64{inputAddress: 400}); err != nil {
+		t.Fatal(err)
+	}
+
+	// apply a valid transfer
+	transfer, transferTransactions := generateTransfer(400)
+	if err := ApplyBundle(transfer, transferTransactions); err != nil {
+		t.Error(err)
+	}
+	assertBalance(t, inputAddress, 0)
+	assertBalance(t, outputAddress, 400)
+
+	// reject a double spend
+	doubleSpend, doubleSpendTransactions := generateTransfer(300)
+	if err := ApplyBundle(doubleSpend, doubleSpendTransactions); !ErrOverspend.Equals(err) {
+		t.Error("the double spend was not rejected")
+	}
+	assertBalance(t, outputAddress, 400)
+
+	// roll back the transfer and apply the double spend instead
+	if err := RollbackBundle(transfer); err != nil {
+		t.Error(err)
+	}
+	assertBalance(t, inputAddress, 400)
+	assertBalance(t, outputAddress, 0)
+
+	if err := ApplyBundle(doubleSpend, doubleSpendTransactions); err != nil {
+		t.Error(err)
+	}
+	assertBalance(t, inputAddress, 0)
+	assertBalance(t, outputAddress, 300)
+	assertBalance(t, seed.GetAddress(2).GetTrytes(), 100)
+}
+
+func TestLedgerOverflow(t *testing.T) {
+	inputAddress := seed.GetAddress(0).GetTrytes()
+	outputAddress := seed.GetAddress(1).GetTrytes()
+
+	if err := SetBalances(map[trinary.Trytes]int64{inputAddress: 400, outputAddress: math.MaxInt64 - 100}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the transfer is rejected without changing any balance
+	transfer, transferTransactions := generateTransfer(400)
+	if err := ApplyBundle(transfer, transferTransactions); !ErrBalanceOverflow.Equals(err) {
+		t.Error("the overflowing transfer was not rejected")
+	}
+	assertBalance(t, inputAddress, 400)
+	assertBalance(t, outputAddress, math.MaxInt64-100)
+
+	if applied, err := IsBundleApplied(transfer.GetHash()); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.Equal(t, applied, false)
+	}
+}
+
+func generateTransfer(value int64) (*bundle.Bundle, []*value_transaction.ValueTransaction) {
+	bundleFactory := client.NewBundleFactory()
+	bundleFactory.AddInput(seed.GetAddress(0), -400)
+	bundleFactory.AddOutput(seed.GetAddress(1), value, "Testmessage")
+	if value != 400 {
+		bundleFactory.AddOutput(seed.GetAddress(2), 400-value)
+	}
+
+	generatedBundle := bundleFactory.GenerateBundle(meta_transaction.BRANCH_NULL_HASH, meta_transaction.BRANCH_NULL_HASH)
+	transactions := generatedBundle.GetTransactions()
+
+	valueBundle := bundle.New(transactions[0].GetHash())
+	valueBundle.SetValueBundle(true)
+	valueBundle.SetBundleEssenceHash(generatedBundle.GetEssenceHash())
+
+	return valueBundle, transactions
+}
+
+func assertBalance(t *testing.T, address trinary.Trytes, expectedBalance int64) {
+	balance, err := GetBalance(address)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, balance, expectedBalance, "wrong balance of "+address)
+}
@@ -0,0 +1,32 @@
+package ledger
+
+import (
+	"github.com/iotaledger/goshimmer/packages/model/bundle"
+	"github.com/iotaledger/goshimmer/packages/model/value_transaction"
+	"github.com/iotaledger/goshimmer/plugins/bundleprocessor"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/node"
+)
+
+// region plugin module setup //////////////////////////////////////////////////////////////////////////////////////////
+
+var PLUGIN = node.NewPlugin("Ledger", node.Enabled, configure, run)
+var log = logger.NewLogger("Ledger")
+
+func configure(plugin *node.Plugin) {
+	bundleprocessor.Events.BundleSolid.Attach(events.NewClosure(func(solidBundle *bundle.Bundle, bundleTransactions []*value_transaction.ValueTransaction) {
+		if !solidBundle.IsValueBundle() {
+			return
+		}
+
+		if err := ApplyBundle(solidBundle, bundleTransactions); err != nil {
+			log.Warningf("Unable to apply bundle %s: %s", solidBundle.GetHash(), err.Error())
+		}
+	}))
+}
+
+func run(plugin *node.Plugin) {
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+)
+
+// public api method to retrieve the last statistics of the database (nil if they were not measured yet)
+func GetDatabaseStats() *database.Stats {
+	databaseStatsMutex.RLock()
+	defer databaseStatsMutex.RUnlock()
+
+	return databaseStats
+}
+
+// public api method to retrieve the amount of value log files that were rewritten by the garbage collection
+func GetRewrittenValueLogFiles() uint64 {
+	return atomic.LoadUint64(&rewrittenValueLogFiles)
+}
+
+// last statistics of the database
+var databaseStats *database.Stats
+
+var databaseStatsMutex sync.RWMutex
+
+// counter for the value log files that were rewritten by the garbage collection
+var rewrittenValueLogFiles uint64
+
+// stores the statistics of the database
+func updateDatabaseStats(stats *database.Stats) {
+	databaseStatsMutex.Lock()
+	databaseStats = stats
+	databaseStatsMutex.Unlock()
+}
+
+// increases the counter of rewritten value log files
+func increaseRewrittenValueLogFiles(rewrittenFiles int) {
+	atomic.AddUint64(&rewrittenValueLogFiles, uint64(rewrittenFiles))
+}
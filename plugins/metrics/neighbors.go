@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/goshimmer/plugins/gossip"
+)
+
+// public api method to retrieve the metrics of the neighbors (indexed by their identifier)
+func GetNeighborMetrics() map[string]NeighborMetrics {
+	neighborMetricsMutex.RLock()
+	defer neighborMetricsMutex.RUnlock()
+
+	result := make(map[string]NeighborMetrics, len(neighborMetrics))
+	for identifier, metrics := range neighborMetrics {
+		result[identifier] = *metrics
+	}
+
+	return result
+}
+
+// NeighborMetrics contains the measured values of a single neighbor.
+type NeighborMetrics struct {
+	RoundTripTime     time.Duration
+	LastHeartbeat     time.Time
+	HeartbeatTimeouts uint64
+	Statistics        gossip.NeighborStatistics
+	ReceivedTPS       uint64
+	SentTPS           uint64
+}
+
+// metrics of the neighbors
+var neighborMetrics = make(map[string]*NeighborMetrics)
+
+var neighborMetricsMutex sync.RWMutex
+
+// stores the round trip time of the heartbeat that was received from the neighbor
+func updateNeighborRoundTripTime(neighbor *gossip.Neighbor, roundTripTime time.Duration) {
+	updateNeighborMetrics(neighbor, func(metrics *NeighborMetrics) {
+		metrics.RoundTripTime = roundTripTime
+		metrics.LastHeartbeat = time.Now()
+	})
+}
+
+// increases the counter of connections that were dropped because of missing heartbeats
+func increaseNeighborHeartbeatTimeouts(neighbor *gossip.Neighbor) {
+	updateNeighborMetrics(neighbor, func(metrics *NeighborMetrics) {
+		metrics.HeartbeatTimeouts++
+	})
+}
+
+// samples the traffic counters of the neighbors and derives the transaction rates since the last measurement
+func measureNeighborStatistics() {
+	for _, neighbor := range gossip.GetNeighbors() {
+		statistics := neighbor.GetStatistics()
+
+		updateNeighborMetrics(neighbor, func(metrics *NeighborMetrics) {
+			metrics.ReceivedTPS = statistics.ReceivedTransactions - metrics.Statistics.ReceivedTransactions
+			metrics.SentTPS = statistics.SentTransactions - metrics.Statistics.SentTransactions
+			metrics.Statistics = statistics
+		})
+	}
+}
+
+// removes the metrics of a neighbor that is not known anymore
+func removeNeighborMetrics(neighbor *gossip.Neighbor) {
+	neighborMetricsMutex.Lock()
+	delete(neighborMetrics, neighbor.GetIdentity().StringIdentifier)
+	neighborMetricsMutex.Unlock()
+}
+
+func updateNeighborMetrics(neighbor *gossip.Neighbor, update func(metrics *NeighborMetrics)) {
+	identifier := neighbor.GetIdentity().StringIdentifier
+
+	neighborMetricsMutex.Lock()
+	defer neighborMetricsMutex.Unlock()
+
+	metrics, exists := neighborMetrics[identifier]
+	if !exists {
+		metrics = &NeighborMetrics{}
+		neighborMetrics[identifier] = metrics
+	}
+
+	update(metrics)
+}
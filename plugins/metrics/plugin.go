@@ -5,6 +5,7 @@ import (
 
 	"github.com/iotaledger/goshimmer/packages/model/meta_transaction"
 	"github.com/iotaledger/goshimmer/packages/timeutil"
+	"github.com/iotaledger/goshimmer/plugins/database"
 	"github.com/iotaledger/goshimmer/plugins/gossip"
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/events"
@@ -16,9 +17,21 @@ var PLUGIN = node.NewPlugin("Metrics", node.Enabled, configure, run)
 func configure(plugin *node.Plugin) {
 	// increase received TPS counter whenever we receive a new transaction
 	gossip.Events.ReceiveTransaction.Attach(events.NewClosure(func(_ *meta_transaction.MetaTransaction) { increaseReceivedTPSCounter() }))
+
+	// keep track of the size of the database
+	database.Events.StatsUpdated.Attach(events.NewClosure(updateDatabaseStats))
+	database.Events.GarbageCollected.Attach(events.NewClosure(increaseRewrittenValueLogFiles))
+
+	// keep track of the liveness of the neighbors
+	gossip.Events.ReceiveHeartbeat.Attach(events.NewClosure(updateNeighborRoundTripTime))
+	gossip.Events.HeartbeatTimeout.Attach(events.NewClosure(increaseNeighborHeartbeatTimeouts))
+	gossip.Events.RemoveNeighbor.Attach(events.NewClosure(removeNeighborMetrics))
 }
 
 func run(plugin *node.Plugin) {
 	// create a background worker that "measures" the TPS value every second
 	daemon.BackgroundWorker("Metrics TPS Updater", func() { timeutil.Ticker(measureReceivedTPS, 1*time.Second) })
+
+	// create a background worker that samples the statistics of the neighbors every second
+	daemon.BackgroundWorker("Metrics Neighbor Statistics Updater", func() { timeutil.Ticker(measureNeighborStatistics, 1*time.Second) })
 }
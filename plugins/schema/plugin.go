@@ -0,0 +1,35 @@
+package schema
+
+import (
+	"github.com/iotaledger/goshimmer/packages/schema"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/node"
+)
+
+// region plugin module setup //////////////////////////////////////////////////////////////////////////////////////////
+
+// PLUGIN has to run before the plugins that use the database (only the database plugin, which restores backups, runs
+// earlier), so the other plugins only see the current schema.
+var PLUGIN = node.NewPlugin("Schema", node.Enabled, configure)
+var log = logger.NewLogger("Schema")
+
+func configure(plugin *node.Plugin) {
+	version, err := schema.GetVersion()
+	if err != nil {
+		panic(err)
+	}
+
+	if version == schema.CURRENT_VERSION {
+		return
+	}
+
+	log.Infof("Migrating database from schema version %d to %d ...", version, schema.CURRENT_VERSION)
+	if err := schema.Migrate(func(migration *schema.Migration) {
+		log.Infof("Migrating to schema version %d (%s) ...", migration.Version, migration.Description)
+	}); err != nil {
+		panic(err)
+	}
+	log.Infof("Migrating database from schema version %d to %d ... done", version, schema.CURRENT_VERSION)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,15 @@
+package snapshot
+
+import (
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	CFG_IMPORT_PATH = "snapshot.importPath"
+	CFG_EXPORT_PATH = "snapshot.exportPath"
+)
+
+func init() {
+	flag.String(CFG_IMPORT_PATH, "", "path of a snapshot file that gets imported at startup (empty = disabled)")
+	flag.String(CFG_EXPORT_PATH, "", "path of the snapshot file that gets written from the databases of the running node (empty = disabled)")
+}
@@ -0,0 +1,43 @@
+package snapshot
+
+import (
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/parameter"
+)
+
+// region plugin module setup //////////////////////////////////////////////////////////////////////////////////////////
+
+// The plugin has to be registered before the tangle plugin, so the imported solid entry points are known before the
+// tangle starts solidifying transactions.
+var PLUGIN = node.NewPlugin("Snapshot", node.Enabled, configure, run)
+var log = logger.NewLogger("Snapshot")
+
+func configure(plugin *node.Plugin) {
+	if importPath := parameter.NodeConfig.GetString(CFG_IMPORT_PATH); importPath != "" {
+		log.Infof("Importing snapshot from %s ...", importPath)
+		if err := Import(importPath); err != nil {
+			panic(err)
+		}
+		log.Infof("Importing snapshot from %s ... done", importPath)
+	}
+}
+
+func run(plugin *node.Plugin) {
+	exportPath := parameter.NodeConfig.GetString(CFG_EXPORT_PATH)
+	if exportPath == "" {
+		return
+	}
+
+	daemon.BackgroundWorker("Snapshot Export", func() {
+		log.Infof("Exporting snapshot to %s ...", exportPath)
+		if err := Export(exportPath); err != nil {
+			log.Errorf("Unable to export snapshot: %s", err.Error())
+		} else {
+			log.Infof("Exporting snapshot to %s ... done", exportPath)
+		}
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,96 @@
+package snapshot
+
+import (
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/goshimmer/packages/model/value_transaction"
+	snapshotfile "github.com/iotaledger/goshimmer/packages/snapshot"
+	"github.com/iotaledger/goshimmer/plugins/ledger"
+	"github.com/iotaledger/goshimmer/plugins/tangle"
+	"github.com/iotaledger/goshimmer/plugins/tipselection"
+	"github.com/iotaledger/iota.go/trinary"
+)
+
+// region public api ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Loads the snapshot file and uses its content as the starting point of the node. The tips of the snapshot become solid
+// entry points as well, since their transactions are not known to the node.
+func Import(fileName string) errors.IdentifiableError {
+	snapshot, err := snapshotfile.FromFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	solidEntryPoints := make([]trinary.Trytes, 0, len(snapshot.SolidEntryPoints)+len(snapshot.Tips))
+	solidEntryPoints = append(solidEntryPoints, snapshot.SolidEntryPoints...)
+	solidEntryPoints = append(solidEntryPoints, snapshot.Tips...)
+	if err := tangle.SetSolidEntryPoints(solidEntryPoints); err != nil {
+		return err
+	}
+
+	if err := ledger.SetBalances(snapshot.Balances); err != nil {
+		return err
+	}
+
+	for _, tip := range snapshot.Tips {
+		tipselection.AddTip(tip)
+	}
+
+	return nil
+}
+
+// Writes the solid entry points, the tips and the balances of the node to the snapshot file.
+func Export(fileName string) errors.IdentifiableError {
+	snapshot := snapshotfile.New()
+	snapshot.SolidEntryPoints = tangle.GetSolidEntryPoints()
+
+	if tips, err := getSolidTips(); err != nil {
+		return err
+	} else {
+		snapshot.Tips = tips
+	}
+
+	if balances, err := ledger.GetBalances(); err != nil {
+		return err
+	} else {
+		snapshot.Balances = balances
+	}
+
+	return snapshot.WriteToFile(fileName)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utility methods //////////////////////////////////////////////////////////////////////////////////////////////
+
+// returns the solid transactions of the database that are not referenced by any other stored transaction
+func getSolidTips() (result []trinary.Trytes, err errors.IdentifiableError) {
+	storedTransactions := make([]trinary.Trytes, 0)
+	referencedTransactions := make(map[trinary.Trytes]bool)
+	if err = tangle.ForEachTransaction(func(transaction *value_transaction.ValueTransaction) {
+		storedTransactions = append(storedTransactions, transaction.GetHash())
+		referencedTransactions[transaction.GetTrunkTransactionHash()] = true
+		referencedTransactions[transaction.GetBranchTransactionHash()] = true
+	}); err != nil {
+		return
+	}
+
+	result = make([]trinary.Trytes, 0)
+	for _, transactionHash := range storedTransactions {
+		if referencedTransactions[transactionHash] {
+			continue
+		}
+
+		if cachedMetadata, metadataErr := tangle.GetTransactionMetadata(transactionHash); metadataErr != nil {
+			return nil, metadataErr
+		} else if cachedMetadata != nil {
+			if cachedMetadata.Unwrap().GetSolid() {
+				result = append(result, transactionHash)
+			}
+			cachedMetadata.Release()
+		}
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
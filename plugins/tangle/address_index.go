@@ -0,0 +1,184 @@
+package tangle
+
+import (
+	"sync"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/goshimmer/packages/model/value_transaction"
+	"github.com/iotaledger/goshimmer/packages/typeutils"
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/parameter"
+	"github.com/iotaledger/iota.go/trinary"
+)
+
+// region plugin module setup //////////////////////////////////////////////////////////////////////////////////////////
+
+func configureAddressIndex(plugin *node.Plugin) {
+	if db, err := database.Get("addressIndex"); err != nil {
+		panic(err)
+	} else {
+		addressIndexDatabase = db
+	}
+
+	Events.TransactionStored.Attach(events.NewClosure(func(transaction *value_transaction.ValueTransaction) {
+		if err := addToAddressIndex(transaction); err != nil {
+			log.Errorf("Unable to index transaction %s: %s", transaction.GetHash(), err.Error())
+		}
+	}))
+
+	Events.TransactionPruned.Attach(events.NewClosure(func(transaction *value_transaction.ValueTransaction) {
+		if err := removeFromAddressIndex(transaction); err != nil {
+			log.Errorf("Unable to remove transaction %s from the address index: %s", transaction.GetHash(), err.Error())
+		}
+	}))
+}
+
+func runAddressIndex(plugin *node.Plugin) {
+	if !parameter.NodeConfig.GetBool(CFG_REBUILD_ADDRESS_INDEX) {
+		return
+	}
+
+	// the shutdown waits for the rebuild before it closes the database
+	addressIndexRebuild.Add(1)
+
+	daemon.BackgroundWorker("Tangle Address Index Rebuild", func() {
+		defer addressIndexRebuild.Done()
+
+		log.Info("Rebuilding address index ...")
+		if err := rebuildAddressIndex(daemon.ShutdownSignal); err != nil {
+			log.Errorf("Unable to rebuild the address index: %s", err.Error())
+		} else {
+			log.Info("Rebuilding address index ... done")
+		}
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region public api ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Returns the hashes of all stored transactions that use the given address.
+func GetTransactionHashesByAddress(address trinary.Trytes) (result []trinary.Trytes, err errors.IdentifiableError) {
+	result, _, err = GetTransactionHashesByAddressPage(address, "", 0)
+
+	return
+}
+
+// Returns at most limit (0 = unlimited) hashes of the stored transactions that use the given address. The hashes are
+// ordered and the page starts after the given cursor (empty for the first page). If there are more hashes, the returned
+// cursor is not empty and can be used to retrieve the next page.
+func GetTransactionHashesByAddressPage(address trinary.Trytes, cursor trinary.Trytes, limit int) (result []trinary.Trytes, nextCursor trinary.Trytes, err errors.IdentifiableError) {
+	result = make([]trinary.Trytes, 0)
+
+	var after []byte
+	if cursor != "" {
+		after = getAddressIndexKey(address, cursor)
+	}
+
+	lastKey, dbErr := addressIndexDatabase.Iterate(func(key []byte, value []byte) bool {
+		result = append(result, trinary.Trytes(string(key[ADDRESS_INDEX_ADDRESS_SIZE:])))
+
+		return true
+	}, database.KeysOnly(), database.WithPrefix(typeutils.StringToBytes(address)), database.Limit(limit), database.After(after))
+	if dbErr != nil {
+		err = ErrDatabaseError.Derive(dbErr, "failed to iterate over the address index")
+	} else if lastKey != nil {
+		nextCursor = trinary.Trytes(string(lastKey[ADDRESS_INDEX_ADDRESS_SIZE:]))
+	}
+
+	return
+}
+
+// Recreates the address index from the stored transactions.
+func RebuildAddressIndex() errors.IdentifiableError {
+	return rebuildAddressIndex(nil)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region database /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var addressIndexDatabase database.Database
+
+var addressIndexRebuild sync.WaitGroup
+
+// recreates the address index and stops early once the abort channel is closed
+func rebuildAddressIndex(abort <-chan int) errors.IdentifiableError {
+	obsoleteKeys := make([][]byte, 0)
+	if _, err := addressIndexDatabase.Iterate(func(key []byte, value []byte) bool {
+		obsoleteKeys = append(obsoleteKeys, append([]byte{}, key...))
+
+		return true
+	}, database.KeysOnly()); err != nil {
+		return ErrDatabaseError.Derive(err, "failed to iterate over the address index")
+	}
+
+	for _, key := range obsoleteKeys {
+		if isClosed(abort) {
+			return ErrShutdown.Derive(errors.New("shutdown"), "the address index rebuild was aborted")
+		}
+
+		if err := addressIndexDatabase.Delete(key); err != nil {
+			return ErrDatabaseError.Derive(err, "failed to remove address index entry")
+		}
+	}
+
+	var indexErr errors.IdentifiableError
+	if err := ForEachTransaction(func(transaction *value_transaction.ValueTransaction) {
+		if indexErr == nil {
+			if isClosed(abort) {
+				indexErr = ErrShutdown.Derive(errors.New("shutdown"), "the address index rebuild was aborted")
+			} else {
+				indexErr = addToAddressIndex(transaction)
+			}
+		}
+	}); err != nil {
+		return err
+	}
+
+	return indexErr
+}
+
+func addToAddressIndex(transaction *value_transaction.ValueTransaction) errors.IdentifiableError {
+	if err := addressIndexDatabase.Set(getAddressIndexKey(transaction.GetAddress(), transaction.GetHash()), []byte{}); err != nil {
+		return ErrDatabaseError.Derive(err, "failed to store address index entry")
+	}
+
+	return nil
+}
+
+func removeFromAddressIndex(transaction *value_transaction.ValueTransaction) errors.IdentifiableError {
+	if err := addressIndexDatabase.Delete(getAddressIndexKey(transaction.GetAddress(), transaction.GetHash())); err != nil {
+		return ErrDatabaseError.Derive(err, "failed to remove address index entry")
+	}
+
+	return nil
+}
+
+func isClosed(channel <-chan int) bool {
+	select {
+	case <-channel:
+		return true
+	default:
+		return false
+	}
+}
+
+// the key consists of the address followed by the transaction hash, so the transactions of an address share a prefix
+func getAddressIndexKey(address trinary.Trytes, transactionHash trinary.Trytes) []byte {
+	key := make([]byte, ADDRESS_INDEX_ADDRESS_SIZE+ADDRESS_INDEX_HASH_SIZE)
+	copy(key[:ADDRESS_INDEX_ADDRESS_SIZE], typeutils.StringToBytes(address))
+	copy(key[ADDRESS_INDEX_ADDRESS_SIZE:], typeutils.StringToBytes(transactionHash))
+
+	return key
+}
+
+const (
+	ADDRESS_INDEX_ADDRESS_SIZE = 81
+	ADDRESS_INDEX_HASH_SIZE    = 81
+)
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,78 @@
+package tangle
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/iotaledger/goshimmer/packages/model/value_transaction"
+	"github.com/iotaledger/iota.go/trinary"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestAddressIndex(t *testing.T) {
+	configureAddressIndex(nil)
+
+	address := trinary.Trytes("ADDRESS9INDEX9TEST999999999999999999999999999999999999999999999999999999999999999")
+	otherAddress := trinary.Trytes("OTHER9ADDRESS9INDEX9TEST999999999999999999999999999999999999999999999999999999999")
+
+	transactions := make([]*value_transaction.ValueTransaction, 3)
+	transactionHashes := make([]trinary.Trytes, 3)
+	for i := range transactions {
+		transactions[i] = value_transaction.New()
+		transactions[i].SetAddress(address)
+		transactions[i].SetValue(int64(i + 1))
+		transactionHashes[i] = transactions[i].GetHash()
+	}
+	sort.Slice(transactionHashes, func(i, j int) bool {
+		return transactionHashes[i] < transactionHashes[j]
+	})
+
+	otherTransaction := value_transaction.New()
+	otherTransaction.SetAddress(otherAddress)
+
+	// stored transactions are indexed
+	for _, transaction := range append(transactions, otherTransaction) {
+		Events.TransactionStored.Trigger(transaction)
+	}
+	assert.Equal(t, mustGetTransactionHashesByAddress(t, address), transactionHashes)
+	assert.Equal(t, mustGetTransactionHashesByAddress(t, otherAddress), []trinary.Trytes{otherTransaction.GetHash()})
+
+	// the pages continue after the cursor
+	firstPage, cursor, err := GetTransactionHashesByAddressPage(address, "", 2)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, firstPage, transactionHashes[:2])
+	assert.Equal(t, cursor, transactionHashes[1])
+
+	secondPage, cursor, err := GetTransactionHashesByAddressPage(address, cursor, 2)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, secondPage, transactionHashes[2:])
+	assert.Equal(t, cursor, trinary.Trytes(""))
+
+	// pruned transactions are removed from the index
+	Events.TransactionPruned.Trigger(otherTransaction)
+	assert.Equal(t, mustGetTransactionHashesByAddress(t, otherAddress), []trinary.Trytes{})
+
+	// the rebuild only indexes the stored transactions
+	StoreTransaction(otherTransaction)
+	if err := RebuildAddressIndex(); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, mustGetTransactionHashesByAddress(t, address), []trinary.Trytes{})
+	assert.Equal(t, mustGetTransactionHashesByAddress(t, otherAddress), []trinary.Trytes{otherTransaction.GetHash()})
+
+	// the rebuild stops writing once the node shuts down
+	abort := make(chan int)
+	close(abort)
+	err = rebuildAddressIndex(abort)
+	assert.Equal(t, ErrShutdown.Equals(err), true)
+	assert.Equal(t, mustGetTransactionHashesByAddress(t, otherAddress), []trinary.Trytes{otherTransaction.GetHash()})
+}
+
+func mustGetTransactionHashesByAddress(t *testing.T, address trinary.Trytes) []trinary.Trytes {
+	transactionHashes, err := GetTransactionHashesByAddress(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return transactionHashes
+}
@@ -1,124 +1,77 @@
 package tangle
 
 import (
-	"github.com/iotaledger/goshimmer/packages/database"
-	"github.com/iotaledger/goshimmer/packages/datastructure"
 	"github.com/iotaledger/goshimmer/packages/errors"
 	"github.com/iotaledger/goshimmer/packages/model/approvers"
-	"github.com/iotaledger/goshimmer/packages/typeutils"
-	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/goshimmer/packages/objectstorage"
 	"github.com/iotaledger/iota.go/trinary"
 )
 
 // region global public api ////////////////////////////////////////////////////////////////////////////////////////////
 
-// GetApprovers retrieves approvers from the database.
-func GetApprovers(transactionHash trinary.Trytes, computeIfAbsent ...func(trinary.Trytes) *approvers.Approvers) (result *approvers.Approvers, err errors.IdentifiableError) {
-	if cacheResult := approversCache.ComputeIfAbsent(transactionHash, func() interface{} {
-		if dbApprovers, dbErr := getApproversFromDatabase(transactionHash); dbErr != nil {
-			err = dbErr
-
-			return nil
-		} else if dbApprovers != nil {
-			return dbApprovers
-		} else {
-			if len(computeIfAbsent) >= 1 {
-				return computeIfAbsent[0](transactionHash)
-			}
-
-			return nil
+// GetApprovers retrieves approvers from the database. The returned reference has to be released after it was used.
+func GetApprovers(transactionHash trinary.Trytes, computeIfAbsent ...func(trinary.Trytes) *approvers.Approvers) (*CachedApprovers, errors.IdentifiableError) {
+	var computeApprovers func(string) objectstorage.StorableObject
+	if len(computeIfAbsent) >= 1 {
+		computeApprovers = func(key string) objectstorage.StorableObject {
+			return computeIfAbsent[0](key)
 		}
-	}); cacheResult != nil && cacheResult.(*approvers.Approvers) != nil {
-		result = cacheResult.(*approvers.Approvers)
 	}
 
-	return
-}
-
-func ContainsApprovers(transactionHash trinary.Trytes) (result bool, err errors.IdentifiableError) {
-	if approversCache.Contains(transactionHash) {
-		result = true
+	if cachedApprovers, err := approversStorage.Load(transactionHash, computeApprovers); err != nil || cachedApprovers == nil {
+		return nil, err
 	} else {
-		result, err = databaseContainsApprovers(transactionHash)
+		return &CachedApprovers{cachedApprovers}, nil
 	}
+}
 
-	return
+func ContainsApprovers(transactionHash trinary.Trytes) (bool, errors.IdentifiableError) {
+	return approversStorage.Contains(transactionHash)
 }
 
 func StoreApprovers(approvers *approvers.Approvers) {
-	approversCache.Set(approvers.GetHash(), approvers)
+	approversStorage.Store(approvers.GetHash(), approvers).Release()
 }
 
-// region lru cache ////////////////////////////////////////////////////////////////////////////////////////////////////
-
-var approversCache = datastructure.NewLRUCache(APPROVERS_CACHE_SIZE, &datastructure.LRUCacheOptions{
-	EvictionCallback: onEvictApprovers,
-})
-
-func onEvictApprovers(_ interface{}, value interface{}) {
-	if evictedApprovers := value.(*approvers.Approvers); evictedApprovers.GetModified() {
-		go func(evictedApprovers *approvers.Approvers) {
-			if err := storeApproversInDatabase(evictedApprovers); err != nil {
-				panic(err)
-			}
-		}(evictedApprovers)
-	}
+// removes the approvers from the cache and the database without writing pending changes
+func deleteApprovers(transactionHash trinary.Trytes) errors.IdentifiableError {
+	return approversStorage.Delete(transactionHash)
 }
 
-const (
-	APPROVERS_CACHE_SIZE = 50000
-)
-
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// region database /////////////////////////////////////////////////////////////////////////////////////////////////////
-
-var approversDatabase database.Database
+// region cached approvers /////////////////////////////////////////////////////////////////////////////////////////////
 
-func configureApproversDatabase(plugin *node.Plugin) {
-	if db, err := database.Get("approvers"); err != nil {
-		panic(err)
-	} else {
-		approversDatabase = db
-	}
+// CachedApprovers is a reference to the approvers of a transaction that keeps them in memory until it is released.
+type CachedApprovers struct {
+	*objectstorage.CachedObject
 }
 
-func storeApproversInDatabase(approvers *approvers.Approvers) errors.IdentifiableError {
-	if approvers.GetModified() {
-		if err := approversDatabase.Set(typeutils.StringToBytes(approvers.GetHash()), approvers.Marshal()); err != nil {
-			return ErrDatabaseError.Derive(err, "failed to store approvers")
-		}
+func (cachedApprovers *CachedApprovers) Unwrap() *approvers.Approvers {
+	return cachedApprovers.Get().(*approvers.Approvers)
+}
 
-		approvers.SetModified(false)
-	}
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
-	return nil
-}
+// region object storage //////////////////////////////////////////////////////////////////////////////////////////////
 
-func getApproversFromDatabase(transactionHash trinary.Trytes) (*approvers.Approvers, errors.IdentifiableError) {
-	approversData, err := approversDatabase.Get(typeutils.StringToBytes(transactionHash))
-	if err != nil {
-		if err == database.ErrKeyNotFound {
-			return nil, nil
-		}
+var approversStorage = objectstorage.New("approvers", marshalApprovers, unmarshalApprovers, objectstorage.CacheSize(APPROVERS_CACHE_SIZE))
 
-		return nil, ErrDatabaseError.Derive(err, "failed to retrieve approvers")
-	}
+func marshalApprovers(object objectstorage.StorableObject) ([]byte, error) {
+	return object.(*approvers.Approvers).Marshal(), nil
+}
 
+func unmarshalApprovers(key []byte, data []byte) (objectstorage.StorableObject, error) {
 	var result approvers.Approvers
-	if err = result.Unmarshal(approversData); err != nil {
-		panic(err)
+	if err := result.Unmarshal(data); err != nil {
+		return nil, err
 	}
 
 	return &result, nil
 }
 
-func databaseContainsApprovers(transactionHash trinary.Trytes) (bool, errors.IdentifiableError) {
-	if contains, err := approversDatabase.Contains(typeutils.StringToBytes(transactionHash)); err != nil {
-		return false, ErrDatabaseError.Derive(err, "failed to check if the approvers exists")
-	} else {
-		return contains, nil
-	}
-}
+const (
+	APPROVERS_CACHE_SIZE = 50000
+)
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
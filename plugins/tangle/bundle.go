@@ -1,128 +1,89 @@
 package tangle
 
 import (
-	"github.com/iotaledger/goshimmer/packages/database"
-	"github.com/iotaledger/goshimmer/packages/datastructure"
 	"github.com/iotaledger/goshimmer/packages/errors"
 	"github.com/iotaledger/goshimmer/packages/model/bundle"
-	"github.com/iotaledger/goshimmer/packages/typeutils"
-	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/goshimmer/packages/objectstorage"
 	"github.com/iotaledger/iota.go/trinary"
 )
 
 // region global public api ////////////////////////////////////////////////////////////////////////////////////////////
 
-// GetBundle retrieves bundle from the database.
-func GetBundle(headerTransactionHash trinary.Trytes, computeIfAbsent ...func(trinary.Trytes) (*bundle.Bundle, errors.IdentifiableError)) (result *bundle.Bundle, err errors.IdentifiableError) {
-	if cacheResult := bundleCache.ComputeIfAbsent(headerTransactionHash, func() interface{} {
-		if dbBundle, dbErr := getBundleFromDatabase(headerTransactionHash); dbErr != nil {
-			err = dbErr
-
-			return nil
-		} else if dbBundle != nil {
-			return dbBundle
-		} else {
-			if len(computeIfAbsent) >= 1 {
-				if computedBundle, computedErr := computeIfAbsent[0](headerTransactionHash); computedErr != nil {
-					err = computedErr
-				} else {
-					return computedBundle
-				}
+// GetBundle retrieves bundle from the database. The returned reference has to be released after it was used.
+func GetBundle(headerTransactionHash trinary.Trytes, computeIfAbsent ...func(trinary.Trytes) (*bundle.Bundle, errors.IdentifiableError)) (*CachedBundle, errors.IdentifiableError) {
+	var computeErr errors.IdentifiableError
+	var computeBundle func(string) objectstorage.StorableObject
+	if len(computeIfAbsent) >= 1 {
+		computeBundle = func(key string) objectstorage.StorableObject {
+			computedBundle, err := computeIfAbsent[0](key)
+			if err != nil {
+				computeErr = err
+
+				return nil
 			}
 
-			return nil
+			return computedBundle
 		}
-	}); cacheResult != nil && cacheResult.(*bundle.Bundle) != nil {
-		result = cacheResult.(*bundle.Bundle)
 	}
 
-	return
-}
-
-func ContainsBundle(headerTransactionHash trinary.Trytes) (result bool, err errors.IdentifiableError) {
-	if bundleCache.Contains(headerTransactionHash) {
-		result = true
+	if cachedBundle, err := bundleStorage.Load(headerTransactionHash, computeBundle); err != nil {
+		return nil, err
+	} else if computeErr != nil {
+		return nil, computeErr
+	} else if cachedBundle == nil {
+		return nil, nil
 	} else {
-		result, err = databaseContainsBundle(headerTransactionHash)
+		return &CachedBundle{cachedBundle}, nil
 	}
+}
 
-	return
+func ContainsBundle(headerTransactionHash trinary.Trytes) (bool, errors.IdentifiableError) {
+	return bundleStorage.Contains(headerTransactionHash)
 }
 
 func StoreBundle(bundle *bundle.Bundle) {
-	bundleCache.Set(bundle.GetHash(), bundle)
+	bundleStorage.Store(bundle.GetHash(), bundle).Release()
 }
 
-// region lru cache ////////////////////////////////////////////////////////////////////////////////////////////////////
-
-var bundleCache = datastructure.NewLRUCache(BUNDLE_CACHE_SIZE, &datastructure.LRUCacheOptions{
-	EvictionCallback: onEvictBundle,
-})
-
-func onEvictBundle(_ interface{}, value interface{}) {
-	if evictedBundle := value.(*bundle.Bundle); evictedBundle.GetModified() {
-		go func(evictedBundle *bundle.Bundle) {
-			if err := storeBundleInDatabase(evictedBundle); err != nil {
-				panic(err)
-			}
-		}(evictedBundle)
-	}
+// removes the bundle from the cache and the database without writing pending changes
+func deleteBundle(headerTransactionHash trinary.Trytes) errors.IdentifiableError {
+	return bundleStorage.Delete(headerTransactionHash)
 }
 
-const (
-	BUNDLE_CACHE_SIZE = 50000
-)
-
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// region database /////////////////////////////////////////////////////////////////////////////////////////////////////
+// region cached bundle ////////////////////////////////////////////////////////////////////////////////////////////////
 
-var bundleDatabase database.Database
-
-func configureBundleDatabase(plugin *node.Plugin) {
-	if db, err := database.Get("bundle"); err != nil {
-		panic(err)
-	} else {
-		bundleDatabase = db
-	}
+// CachedBundle is a reference to a bundle of the cache that keeps it in memory until it is released.
+type CachedBundle struct {
+	*objectstorage.CachedObject
 }
 
-func storeBundleInDatabase(bundle *bundle.Bundle) errors.IdentifiableError {
-	if bundle.GetModified() {
-		if err := bundleDatabase.Set(typeutils.StringToBytes(bundle.GetHash()), bundle.Marshal()); err != nil {
-			return ErrDatabaseError.Derive(err, "failed to store bundle")
-		}
+func (cachedBundle *CachedBundle) Unwrap() *bundle.Bundle {
+	return cachedBundle.Get().(*bundle.Bundle)
+}
 
-		bundle.SetModified(false)
-	}
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
-	return nil
-}
+// region object storage //////////////////////////////////////////////////////////////////////////////////////////////
 
-func getBundleFromDatabase(transactionHash trinary.Trytes) (*bundle.Bundle, errors.IdentifiableError) {
-	bundleData, err := bundleDatabase.Get(typeutils.StringToBytes(transactionHash))
-	if err != nil {
-		if err == database.ErrKeyNotFound {
-			return nil, nil
-		}
+var bundleStorage = objectstorage.New("bundle", marshalBundle, unmarshalBundle, objectstorage.CacheSize(BUNDLE_CACHE_SIZE))
 
-		return nil, ErrDatabaseError.Derive(err, "failed to retrieve bundle")
-	}
+func marshalBundle(object objectstorage.StorableObject) ([]byte, error) {
+	return object.(*bundle.Bundle).Marshal(), nil
+}
 
+func unmarshalBundle(key []byte, data []byte) (objectstorage.StorableObject, error) {
 	var result bundle.Bundle
-	if err = result.Unmarshal(bundleData); err != nil {
-		panic(err)
+	if err := result.Unmarshal(data); err != nil {
+		return nil, err
 	}
 
 	return &result, nil
 }
 
-func databaseContainsBundle(transactionHash trinary.Trytes) (bool, errors.IdentifiableError) {
-	if contains, err := bundleDatabase.Contains(typeutils.StringToBytes(transactionHash)); err != nil {
-		return false, ErrDatabaseError.Derive(err, "failed to check if the bundle exists")
-	} else {
-		return contains, nil
-	}
-}
+const (
+	BUNDLE_CACHE_SIZE = 50000
+)
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,106 @@
+package tangle
+
+import (
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/goshimmer/packages/model/transactionmetadata"
+	"github.com/iotaledger/goshimmer/packages/model/value_transaction"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/parameter"
+	"github.com/iotaledger/iota.go/trinary"
+)
+
+// region plugin module setup //////////////////////////////////////////////////////////////////////////////////////////
+
+func configureConfirmation(plugin *node.Plugin) {
+	confirmationThreshold = uint64(parameter.NodeConfig.GetInt(CFG_CONFIRMATION_THRESHOLD))
+
+	Events.TransactionSolid.Attach(events.NewClosure(func(transaction *value_transaction.ValueTransaction) {
+		if err := updateApprovalWeight(transaction); err != nil {
+			log.Errorf("Unable to update the approval weight of the past cone of %s: %s", transaction.GetHash(), err.Error())
+		}
+	}))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region public api ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Returns the inclusion state of the given transaction. Transactions that are not stored are reported as unknown, the
+// solid entry points are always confirmed.
+func GetInclusionState(transactionHash trinary.Trytes) (result InclusionState, err errors.IdentifiableError) {
+	if IsSolidEntryPoint(transactionHash) {
+		result.Known = true
+		result.Solid = true
+		result.Confirmed = true
+
+		return
+	}
+
+	cachedMetadata, err := GetTransactionMetadata(transactionHash)
+	if err != nil || cachedMetadata == nil {
+		return
+	}
+	defer cachedMetadata.Release()
+
+	metadata := cachedMetadata.Unwrap()
+	result.Known = true
+	result.Solid = metadata.GetSolid()
+	result.Confirmed = metadata.GetConfirmed()
+	result.ApprovalWeight = metadata.GetApprovalWeight()
+
+	return
+}
+
+func IsConfirmed(transactionHash trinary.Trytes) (bool, errors.IdentifiableError) {
+	inclusionState, err := GetInclusionState(transactionHash)
+
+	return inclusionState.Confirmed, err
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utility methods //////////////////////////////////////////////////////////////////////////////////////////////
+
+// Increases the approval weight of all transactions in the past cone of a transaction that just became solid. The walk
+// does not continue behind confirmed transactions since their past cone is confirmed already (its weight can only be
+// higher). The weights are increased atomically, so the past cones of several transactions can be walked concurrently.
+func updateApprovalWeight(transaction *value_transaction.ValueTransaction) errors.IdentifiableError {
+	walker := NewPastConeWalker(transaction.GetHash())
+	for walker.Next() {
+		approvee := walker.Transaction()
+
+		cachedMetadata, err := GetTransactionMetadata(approvee.GetHash(), transactionmetadata.New)
+		if err != nil {
+			return err
+		}
+
+		if metadata := cachedMetadata.Unwrap(); metadata.GetConfirmed() {
+			walker.Skip()
+		} else if metadata.IncreaseApprovalWeight(1) >= confirmationThreshold && metadata.SetConfirmed(true) {
+			Events.TransactionConfirmed.Trigger(approvee)
+		}
+		cachedMetadata.Release()
+	}
+
+	return walker.Err()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region types and interfaces /////////////////////////////////////////////////////////////////////////////////////////
+
+type InclusionState struct {
+	Known          bool
+	Solid          bool
+	Confirmed      bool
+	ApprovalWeight uint64
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region constants and variables //////////////////////////////////////////////////////////////////////////////////////
+
+var confirmationThreshold uint64
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,104 @@
+package tangle
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/iotaledger/goshimmer/packages/model/transactionmetadata"
+	"github.com/iotaledger/goshimmer/packages/model/value_transaction"
+	"github.com/iotaledger/iota.go/trinary"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestConfirmation(t *testing.T) {
+	confirmationThreshold = 2
+
+	// create a chain: 1 <- 2 <- 3 <- 4
+	transaction1 := value_transaction.New()
+	transaction1.SetNonce(trinary.Trytes("99999999999999999999999999C"))
+	transaction2 := value_transaction.New()
+	transaction2.SetBranchTransactionHash(transaction1.GetHash())
+	transaction3 := value_transaction.New()
+	transaction3.SetBranchTransactionHash(transaction2.GetHash())
+	transaction4 := value_transaction.New()
+	transaction4.SetBranchTransactionHash(transaction3.GetHash())
+
+	transactions := []*value_transaction.ValueTransaction{transaction1, transaction2, transaction3, transaction4}
+	for _, transaction := range transactions {
+		StoreTransaction(transaction)
+		StoreTransactionMetadata(transactionmetadata.New(transaction.GetHash()))
+	}
+
+	// the transactions become solid one after another
+	for _, transaction := range transactions {
+		if err := updateApprovalWeight(transaction); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expectedStates := []InclusionState{
+		{Known: true, Confirmed: true, ApprovalWeight: 2},
+		{Known: true, Confirmed: true, ApprovalWeight: 2},
+		{Known: true, Confirmed: false, ApprovalWeight: 1},
+		{Known: true, Confirmed: false, ApprovalWeight: 0},
+	}
+	for i, transaction := range transactions {
+		inclusionState, err := GetInclusionState(transaction.GetHash())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, inclusionState, expectedStates[i])
+	}
+}
+
+func TestConcurrentConfirmation(t *testing.T) {
+	confirmationThreshold = 100
+
+	// create a chain 1 <- 2 and some tips that approve 2
+	transactions := storeChain(t, "99999999999999999999999999L", 2, true)
+	tips := make([]*value_transaction.ValueTransaction, 10)
+	for i := range tips {
+		tips[i] = value_transaction.New()
+		tips[i].SetBranchTransactionHash(transactions[1].GetHash())
+		tips[i].SetValue(int64(i))
+
+		StoreTransaction(tips[i])
+		StoreTransactionMetadata(transactionmetadata.New(tips[i].GetHash()))
+	}
+
+	// the past cones of the tips are walked at the same time
+	var wg sync.WaitGroup
+	wg.Add(len(tips))
+	for _, tip := range tips {
+		go func(tip *value_transaction.ValueTransaction) {
+			defer wg.Done()
+
+			if err := updateApprovalWeight(tip); err != nil {
+				t.Error(err)
+			}
+		}(tip)
+	}
+	wg.Wait()
+
+	for _, transaction := range transactions {
+		inclusionState, err := GetInclusionState(transaction.GetHash())
+		assert.Equal(t, err, nil)
+		assert.Equal(t, inclusionState.ApprovalWeight, uint64(len(tips)))
+	}
+
+	// the walk does not continue behind confirmed transactions
+	cachedMetadata, err := GetTransactionMetadata(transactions[1].GetHash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cachedMetadata.Unwrap().SetConfirmed(true)
+	cachedMetadata.Release()
+
+	if err := updateApprovalWeight(tips[0]); err != nil {
+		t.Fatal(err)
+	}
+	inclusionState, err := GetInclusionState(transactions[0].GetHash())
+	assert.Equal(t, err, nil)
+	assert.Equal(t, inclusionState.ApprovalWeight, uint64(len(tips)))
+}
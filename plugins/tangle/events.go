@@ -6,13 +6,17 @@ import (
 )
 
 var Events = pluginEvents{
-	TransactionStored: events.NewEvent(transactionCaller),
-	TransactionSolid:  events.NewEvent(transactionCaller),
+	TransactionStored:    events.NewEvent(transactionCaller),
+	TransactionSolid:     events.NewEvent(transactionCaller),
+	TransactionConfirmed: events.NewEvent(transactionCaller),
+	TransactionPruned:    events.NewEvent(transactionCaller),
 }
 
 type pluginEvents struct {
-	TransactionStored *events.Event
-	TransactionSolid  *events.Event
+	TransactionStored    *events.Event
+	TransactionSolid     *events.Event
+	TransactionConfirmed *events.Event
+	TransactionPruned    *events.Event
 }
 
 func transactionCaller(handler interface{}, params ...interface{}) {
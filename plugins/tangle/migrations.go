@@ -0,0 +1,121 @@
+package tangle
+
+import (
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/model/transactionmetadata"
+	"github.com/iotaledger/goshimmer/packages/schema"
+)
+
+// region migrations ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Only the layout of the transaction metadata changed so far. The marshaled transactions, approvers and bundles (and the
+// peers of the autopeering) are still written in the layout of version 0, so they need no migration. A change of one of
+// these layouts has to increase the schema version and register a migration here, because their unmarshal functions
+// can not tell the layouts apart (they only reject entries that are too short and return an error instead of panicking).
+func init() {
+	schema.RegisterMigration(1, "add the solidification time and the conflicting flag to the transaction metadata", migrateTransactionMetadataToVersion1)
+	schema.RegisterMigration(2, "add the approval weight and the confirmed flag to the transaction metadata", migrateTransactionMetadataToVersion2)
+}
+
+// Inserts the solidification time after the received time. The solidification time of transactions that are solid
+// already is unknown, so the received time is used instead. The conflicting flag (bit 3) was unused before and needs no
+// conversion.
+func migrateTransactionMetadataToVersion1() error {
+	return migrateTransactionMetadata(VERSION_0_METADATA_SIZE, func(oldData []byte) []byte {
+		newData := make([]byte, VERSION_1_METADATA_SIZE)
+		copy(newData[:VERSION_0_FLAGS_START], oldData[:VERSION_0_FLAGS_START])
+		newData[VERSION_1_FLAGS_START] = oldData[VERSION_0_FLAGS_START]
+
+		// copy the received time if the transaction is solid (flag 0)
+		if oldData[VERSION_0_FLAGS_START]&1 != 0 {
+			copy(newData[VERSION_0_FLAGS_START:VERSION_1_FLAGS_START], oldData[transactionmetadata.MARSHALED_RECEIVED_TIME_START:transactionmetadata.MARSHALED_RECEIVED_TIME_END])
+		} else {
+			copy(newData[VERSION_0_FLAGS_START:VERSION_1_FLAGS_START], marshaledZeroTime())
+		}
+
+		return newData
+	})
+}
+
+// Inserts the approval weight (0) before the flags. The confirmed flag (bit 4) was unused before, so the transactions
+// get confirmed again once new approvers arrive.
+func migrateTransactionMetadataToVersion2() error {
+	return migrateTransactionMetadata(VERSION_1_METADATA_SIZE, func(oldData []byte) []byte {
+		newData := make([]byte, transactionmetadata.MARSHALED_TOTAL_SIZE)
+		copy(newData[:VERSION_1_FLAGS_START], oldData[:VERSION_1_FLAGS_START])
+		newData[transactionmetadata.MARSHALED_FLAGS_START] = oldData[VERSION_1_FLAGS_START]
+
+		return newData
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utility methods //////////////////////////////////////////////////////////////////////////////////////////////
+
+// rewrites all entries of the transaction metadata database that have the old size (entries with a different size were
+// converted already, so an interrupted migration can be repeated) - every page of MIGRATION_PAGE_SIZE entries is
+// committed on its own, so the migration does not keep the whole database in memory
+func migrateTransactionMetadata(oldSize int, convert func(oldData []byte) []byte) error {
+	db, err := database.Get("transactionMetadata")
+	if err != nil {
+		return err
+	}
+
+	var cursor []byte
+	for {
+		batch := database.NewBatch()
+		var batchErr error
+		nextCursor, err := db.Iterate(func(key []byte, value []byte) bool {
+			if len(value) == oldSize {
+				batchErr = batch.Set(db, key, convert(value))
+			}
+
+			return batchErr == nil
+		}, database.Limit(MIGRATION_PAGE_SIZE), database.After(cursor))
+		if err != nil {
+			return err
+		} else if batchErr != nil {
+			return batchErr
+		}
+
+		if err := batch.Commit(); err != nil {
+			return err
+		}
+
+		if nextCursor == nil {
+			return nil
+		}
+		cursor = nextCursor
+	}
+}
+
+func marshaledZeroTime() []byte {
+	var zeroTime time.Time
+	if result, err := zeroTime.MarshalBinary(); err != nil {
+		panic(err)
+	} else {
+		return result
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region constants and variables //////////////////////////////////////////////////////////////////////////////////////
+
+// the layouts of the transaction metadata in the previous schema versions
+const (
+	VERSION_0_FLAGS_START   = transactionmetadata.MARSHALED_RECEIVED_TIME_END
+	VERSION_0_METADATA_SIZE = VERSION_0_FLAGS_START + transactionmetadata.MARSHALED_FLAGS_SIZE
+
+	VERSION_1_FLAGS_START   = VERSION_0_FLAGS_START + transactionmetadata.MARSHALED_SOLIDIFICATION_TIME_SIZE
+	VERSION_1_METADATA_SIZE = VERSION_1_FLAGS_START + transactionmetadata.MARSHALED_FLAGS_SIZE
+)
+
+const (
+	MIGRATION_PAGE_SIZE = 1000
+)
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
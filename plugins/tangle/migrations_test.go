@@ -0,0 +1,99 @@
+package tangle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/model/transactionmetadata"
+	"github.com/iotaledger/goshimmer/packages/typeutils"
+	"github.com/iotaledger/iota.go/trinary"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestMigrateTransactionMetadata(t *testing.T) {
+	transactionHash := trinary.Trytes("MIGRATION999999999999999999999999999999999999999999999999999999999999999999999999")
+	receivedTime := time.Unix(1577836800, 0)
+
+	// marshal the metadata in the layout of version 0 (hash, received time, flags)
+	oldData := make([]byte, VERSION_0_METADATA_SIZE)
+	copy(oldData, typeutils.StringToBytes(transactionHash))
+	marshaledReceivedTime, _ := receivedTime.MarshalBinary()
+	copy(oldData[transactionmetadata.MARSHALED_RECEIVED_TIME_START:], marshaledReceivedTime)
+	oldData[VERSION_0_FLAGS_START] = 1 | 2
+
+	db, err := database.Get("transactionMetadata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Set(typeutils.StringToBytes(transactionHash), oldData); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := migrateTransactionMetadataToVersion1(); err != nil {
+		t.Fatal(err)
+	}
+	if err := migrateTransactionMetadataToVersion2(); err != nil {
+		t.Fatal(err)
+	}
+
+	newData, err := db.Get(typeutils.StringToBytes(transactionHash))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var metadata transactionmetadata.TransactionMetadata
+	if err := metadata.Unmarshal(newData); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(newData), transactionmetadata.MARSHALED_TOTAL_SIZE)
+	assert.Equal(t, metadata.GetHash(), transactionHash)
+	assert.Equal(t, metadata.GetReceivedTime().Equal(receivedTime), true)
+	assert.Equal(t, metadata.GetSolidificationTime().Equal(receivedTime), true)
+	assert.Equal(t, metadata.GetSolid(), true)
+	assert.Equal(t, metadata.GetLiked(), true)
+	assert.Equal(t, metadata.GetConflicting(), false)
+	assert.Equal(t, metadata.GetApprovalWeight(), uint64(0))
+}
+
+func TestMigrateTransactionMetadataPages(t *testing.T) {
+	db, err := database.Get("transactionMetadata")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the entries span several pages of the migration
+	transactionHashes := make([]trinary.Trytes, MIGRATION_PAGE_SIZE+1)
+	for i := range transactionHashes {
+		transactionHashes[i] = trinary.IntToTrytes(int64(i), 81)
+
+		oldData := make([]byte, VERSION_1_METADATA_SIZE)
+		copy(oldData, typeutils.StringToBytes(transactionHashes[i]))
+		if err := db.Set(typeutils.StringToBytes(transactionHashes[i]), oldData); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the entries are removed again, since the other tests share the database
+	defer func() {
+		for _, transactionHash := range transactionHashes {
+			if err := db.Delete(typeutils.StringToBytes(transactionHash)); err != nil {
+				t.Error(err)
+			}
+		}
+	}()
+
+	if err := migrateTransactionMetadataToVersion2(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, transactionHash := range transactionHashes {
+		newData, err := db.Get(typeutils.StringToBytes(transactionHash))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, len(newData), transactionmetadata.MARSHALED_TOTAL_SIZE)
+	}
+}
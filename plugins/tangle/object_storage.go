@@ -0,0 +1,42 @@
+package tangle
+
+import (
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/goshimmer/packages/objectstorage"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/node"
+)
+
+// region plugin module setup //////////////////////////////////////////////////////////////////////////////////////////
+
+func configureObjectStorages(plugin *node.Plugin) {
+	for _, storage := range objectStorages() {
+		storage.Events.Error.Attach(events.NewClosure(func(err errors.IdentifiableError) {
+			log.Errorf("Unable to write to the database: %s", err.Error())
+		}))
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region public api ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Writes all modified objects of the caches to the database (in a single batch).
+func FlushCaches() errors.IdentifiableError {
+	return objectstorage.Flush(objectStorages()...)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utility methods //////////////////////////////////////////////////////////////////////////////////////////////
+
+func objectStorages() []*objectstorage.ObjectStorage {
+	return []*objectstorage.ObjectStorage{
+		transactionStorage,
+		transactionMetadataStorage,
+		approversStorage,
+		bundleStorage,
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
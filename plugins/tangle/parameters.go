@@ -0,0 +1,30 @@
+package tangle
+
+import (
+	"time"
+
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	CFG_PRUNING_INTERVAL = "tangle.pruningInterval"
+	CFG_PRUNING_MAX_AGE  = "tangle.pruningMaxAge"
+	CFG_PRUNING_DEPTH    = "tangle.pruningDepth"
+
+	CFG_SOLIDITY_PROPAGATION_BATCH_SIZE = "tangle.solidityPropagationBatchSize"
+	CFG_SOLIDITY_PROPAGATION_QUEUE_SIZE = "tangle.solidityPropagationQueueSize"
+
+	CFG_REBUILD_ADDRESS_INDEX = "tangle.rebuildAddressIndex"
+
+	CFG_CONFIRMATION_THRESHOLD = "tangle.confirmationThreshold"
+)
+
+func init() {
+	flag.Duration(CFG_PRUNING_INTERVAL, 1*time.Hour, "interval in which the tangle gets pruned")
+	flag.Duration(CFG_PRUNING_MAX_AGE, 0, "solid transactions that were received earlier are pruned (0 = disabled)")
+	flag.Int(CFG_PRUNING_DEPTH, 0, "solid transactions that are further away from the tips are pruned (0 = disabled)")
+	flag.Int(CFG_SOLIDITY_PROPAGATION_BATCH_SIZE, 1000, "amount of solid transactions whose approvers are checked in one step of the solidity propagation")
+	flag.Int(CFG_SOLIDITY_PROPAGATION_QUEUE_SIZE, 100000, "maximum amount of solid transactions that wait for the solidity propagation (the solidifier waits if the queue is full)")
+	flag.Bool(CFG_REBUILD_ADDRESS_INDEX, false, "recreate the address index from the stored transactions at startup")
+	flag.Int(CFG_CONFIRMATION_THRESHOLD, 100, "amount of solid transactions that have to approve a transaction (directly or indirectly) before it is confirmed")
+}
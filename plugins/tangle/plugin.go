@@ -11,15 +11,21 @@ var PLUGIN = node.NewPlugin("Tangle", node.Enabled, configure, run)
 var log = logger.NewLogger("Tangle")
 
 func configure(plugin *node.Plugin) {
-	configureTransactionDatabase(plugin)
-	configureTransactionMetaDataDatabase(plugin)
-	configureApproversDatabase(plugin)
-	configureBundleDatabase(plugin)
+	configureObjectStorages(plugin)
+	configureAddressIndex(plugin)
 	configureSolidifier(plugin)
+	configureSolidityPropagation(plugin)
+	configureConfirmation(plugin)
+	configureRequestHandler(plugin)
+	configureShutdown(plugin)
 }
 
 func run(plugin *node.Plugin) {
 	runSolidifier(plugin)
+	runSolidityPropagation(plugin)
+	runPruning(plugin)
+	runAddressIndex(plugin)
+	runShutdown(plugin)
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,353 @@
+package tangle
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/goshimmer/packages/model/transactionmetadata"
+	"github.com/iotaledger/goshimmer/packages/timeutil"
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/parameter"
+	"github.com/iotaledger/iota.go/trinary"
+)
+
+// region plugin module setup //////////////////////////////////////////////////////////////////////////////////////////
+
+func runPruning(plugin *node.Plugin) {
+	maxAge := parameter.NodeConfig.GetDuration(CFG_PRUNING_MAX_AGE)
+	depth := parameter.NodeConfig.GetInt(CFG_PRUNING_DEPTH)
+	if maxAge == 0 && depth == 0 {
+		return
+	}
+
+	log.Info("Starting Pruning ...")
+
+	daemon.BackgroundWorker("Tangle Pruning", func() {
+		log.Info("Starting Pruning ... done")
+
+		timeutil.Ticker(func() {
+			if prunedTransactions, err := Prune(maxAge, depth); err != nil {
+				log.Errorf("Unable to prune the tangle: %s", err.Error())
+			} else {
+				log.Infof("Pruned %d transactions", prunedTransactions)
+			}
+		}, parameter.NodeConfig.GetDuration(CFG_PRUNING_INTERVAL))
+
+		log.Info("Stopping Pruning ... done")
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region public api ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Removes all solid transactions that were received before maxAge or that are more than depth steps away from the
+// tips (a value of 0 disables the corresponding criterion). The pruned transactions that are still referenced by the
+// remaining transactions become the new solid entry points.
+//
+// The stored transactions are processed in pages, so only the transactions close to the tips (and the solid entry
+// points) are kept in memory.
+func Prune(maxAge time.Duration, depth int) (prunedTransactions int, err errors.IdentifiableError) {
+	pruningMutex.Lock()
+	defer pruningMutex.Unlock()
+
+	// write the cached changes so the database reflects the current state
+	if err = FlushCaches(); err != nil {
+		return
+	}
+
+	// the transactions close to the tips are kept
+	var distances map[trinary.Trytes]int
+	if depth != 0 {
+		tipHashes, tipsErr := getTips()
+		if tipsErr != nil {
+			return 0, tipsErr
+		}
+
+		if distances, err = calculateDistancesToTips(tipHashes, depth); err != nil {
+			return
+		}
+	}
+
+	pruningTime := time.Now()
+	isPruned := func(transactionHash trinary.Trytes, metadata *transactionmetadata.TransactionMetadata) bool {
+		if !metadata.GetSolid() {
+			return false
+		}
+
+		if maxAge != 0 && pruningTime.Sub(metadata.GetReceivedTime()) > maxAge {
+			return true
+		}
+
+		_, reachable := distances[transactionHash]
+
+		return depth != 0 && !reachable
+	}
+
+	transactionMetadataDatabase, err := transactionMetadataStorage.GetDatabase()
+	if err != nil {
+		return
+	}
+
+	// remove the pruned transactions and remember the ones that are still referenced by the remaining transactions
+	newSolidEntryPoints := make(map[trinary.Trytes]bool)
+	if err = forEachPage(transactionMetadataDatabase, func(key []byte, value []byte) (bool, errors.IdentifiableError) {
+		var metadata transactionmetadata.TransactionMetadata
+		if err := metadata.Unmarshal(value); err != nil {
+			return false, err
+		}
+
+		return isPruned(trinary.Trytes(string(key)), &metadata), nil
+	}, func(transactionHash trinary.Trytes) errors.IdentifiableError {
+		referenced, err := hasRemainingApprovers(transactionHash, isPruned)
+		if err != nil {
+			return err
+		}
+
+		if referenced {
+			newSolidEntryPoints[transactionHash] = true
+		}
+
+		if err := pruneTransaction(transactionHash, referenced); err != nil {
+			return err
+		}
+
+		prunedTransactions++
+
+		return nil
+	}); err != nil || prunedTransactions == 0 {
+		return
+	}
+
+	// the previous solid entry points are kept as long as they are referenced
+	for _, transactionHash := range GetSolidEntryPoints() {
+		if newSolidEntryPoints[transactionHash] {
+			continue
+		}
+
+		referenced, referencedErr := hasRemainingApprovers(transactionHash, isPruned)
+		if referencedErr != nil {
+			return prunedTransactions, referencedErr
+		}
+
+		if referenced {
+			newSolidEntryPoints[transactionHash] = true
+		} else if err = deleteApprovers(transactionHash); err != nil {
+			return
+		}
+	}
+
+	solidEntryPointHashes := make([]trinary.Trytes, 0, len(newSolidEntryPoints))
+	for transactionHash := range newSolidEntryPoints {
+		solidEntryPointHashes = append(solidEntryPointHashes, transactionHash)
+	}
+	err = SetSolidEntryPoints(solidEntryPointHashes)
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utility methods //////////////////////////////////////////////////////////////////////////////////////////////
+
+// returns the stored transactions that are not referenced by other transactions
+func getTips() (result []trinary.Trytes, err errors.IdentifiableError) {
+	result = make([]trinary.Trytes, 0)
+
+	transactionDatabase, err := transactionStorage.GetDatabase()
+	if err != nil {
+		return nil, err
+	}
+
+	err = forEachPage(transactionDatabase, nil, func(transactionHash trinary.Trytes) errors.IdentifiableError {
+		if cachedApprovers, err := GetApprovers(transactionHash); err != nil {
+			return err
+		} else if cachedApprovers == nil {
+			result = append(result, transactionHash)
+		} else {
+			if len(cachedApprovers.Unwrap().GetHashes()) == 0 {
+				result = append(result, transactionHash)
+			}
+			cachedApprovers.Release()
+		}
+
+		return nil
+	}, database.KeysOnly())
+
+	return
+}
+
+// walks the past cone of the tips and returns the distance of every transaction that is at most depth steps away from
+// them
+func calculateDistancesToTips(tipHashes []trinary.Trytes, depth int) (distances map[trinary.Trytes]int, err errors.IdentifiableError) {
+	distances = make(map[trinary.Trytes]int)
+
+	queue := make([]trinary.Trytes, 0, len(tipHashes))
+	for _, tipHash := range tipHashes {
+		distances[tipHash] = 0
+
+		queue = append(queue, tipHash)
+	}
+
+	for len(queue) != 0 {
+		transactionHash := queue[0]
+		queue = queue[1:]
+
+		distance := distances[transactionHash]
+		if distance >= depth {
+			continue
+		}
+
+		transaction, loadErr := loadTransaction(transactionHash)
+		if loadErr != nil {
+			return nil, loadErr
+		} else if transaction == nil {
+			continue
+		}
+
+		for _, approveeHash := range []trinary.Trytes{transaction.GetTrunkTransactionHash(), transaction.GetBranchTransactionHash()} {
+			if _, visited := distances[approveeHash]; visited {
+				continue
+			}
+
+			if stored, containsErr := ContainsTransaction(approveeHash); containsErr != nil {
+				return nil, containsErr
+			} else if stored {
+				distances[approveeHash] = distance + 1
+
+				queue = append(queue, approveeHash)
+			}
+		}
+	}
+
+	return
+}
+
+// checks if the transaction is referenced by a transaction that is not pruned
+func hasRemainingApprovers(transactionHash trinary.Trytes, isPruned func(trinary.Trytes, *transactionmetadata.TransactionMetadata) bool) (bool, errors.IdentifiableError) {
+	cachedApprovers, err := GetApprovers(transactionHash)
+	if err != nil || cachedApprovers == nil {
+		return false, err
+	}
+	approverHashes := cachedApprovers.Unwrap().GetHashes()
+	cachedApprovers.Release()
+
+	for _, approverHash := range approverHashes {
+		cachedMetadata, err := GetTransactionMetadata(approverHash)
+		if err != nil {
+			return false, err
+		} else if cachedMetadata == nil {
+			// the approver was pruned already
+			continue
+		}
+
+		remaining := !isPruned(approverHash, cachedMetadata.Unwrap())
+		cachedMetadata.Release()
+
+		if remaining {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Removes the transaction and its related objects. The approvers of transactions that become solid entry points are
+// kept, so the next pruning can check if the solid entry point is still referenced.
+func pruneTransaction(transactionHash trinary.Trytes, keepApprovers bool) errors.IdentifiableError {
+	cachedTransaction, err := GetTransaction(transactionHash)
+	if err != nil {
+		return err
+	}
+
+	if cachedTransaction != nil {
+		transaction := cachedTransaction.Unwrap()
+		cachedTransaction.Release()
+
+		Events.TransactionPruned.Trigger(transaction)
+
+		// unregister the transaction from the approvers of its approvees
+		for _, approveeHash := range []trinary.Trytes{transaction.GetTrunkTransactionHash(), transaction.GetBranchTransactionHash()} {
+			if approveeApprovers, err := GetApprovers(approveeHash); err != nil {
+				return err
+			} else if approveeApprovers != nil {
+				approveeApprovers.Unwrap().Remove(transactionHash)
+				approveeApprovers.Release()
+			}
+		}
+
+		if transaction.IsHead() {
+			if err := deleteBundle(transactionHash); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !keepApprovers {
+		if err := deleteApprovers(transactionHash); err != nil {
+			return err
+		}
+	}
+	if err := deleteTransactionMetadata(transactionHash); err != nil {
+		return err
+	}
+
+	return deleteTransaction(transactionHash)
+}
+
+// Iterates over the database in pages of PRUNING_PAGE_SIZE entries and passes the keys that are selected by the filter
+// (all keys if it is nil) to the consumer. The consumer is called after a page was read, so it can modify the database.
+func forEachPage(db database.Database, filter func(key []byte, value []byte) (bool, errors.IdentifiableError), consumer func(key trinary.Trytes) errors.IdentifiableError, options ...database.IteratorOption) errors.IdentifiableError {
+	var cursor []byte
+	for {
+		var filterErr errors.IdentifiableError
+		selectedKeys := make([]trinary.Trytes, 0)
+
+		nextCursor, dbErr := db.Iterate(func(key []byte, value []byte) bool {
+			if filter != nil {
+				if selected, err := filter(key, value); err != nil {
+					filterErr = err
+
+					return false
+				} else if !selected {
+					return true
+				}
+			}
+
+			selectedKeys = append(selectedKeys, trinary.Trytes(string(key)))
+
+			return true
+		}, append(options, database.Limit(PRUNING_PAGE_SIZE), database.After(cursor))...)
+		if dbErr != nil {
+			return ErrDatabaseError.Derive(dbErr, "failed to iterate over the database")
+		} else if filterErr != nil {
+			return filterErr
+		}
+
+		for _, key := range selectedKeys {
+			if err := consumer(key); err != nil {
+				return err
+			}
+		}
+
+		if nextCursor == nil {
+			return nil
+		}
+		cursor = nextCursor
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region constants and variables //////////////////////////////////////////////////////////////////////////////////////
+
+var pruningMutex sync.Mutex
+
+const (
+	PRUNING_PAGE_SIZE = 1000
+)
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
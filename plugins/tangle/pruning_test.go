@@ -0,0 +1,119 @@
+package tangle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/model/approvers"
+	"github.com/iotaledger/goshimmer/packages/model/transactionmetadata"
+	"github.com/iotaledger/goshimmer/packages/model/value_transaction"
+	"github.com/iotaledger/iota.go/trinary"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestCalculateDistancesToTips(t *testing.T) {
+	// A <- B <- C <- D (D is the only tip)
+	transactions := storeChain(t, "99999999999999999999999999F", 4, true)
+	hashA, hashB, hashC, hashD := transactions[0].GetHash(), transactions[1].GetHash(), transactions[2].GetHash(), transactions[3].GetHash()
+
+	distances, err := calculateDistancesToTips([]trinary.Trytes{hashD}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(distances), 3, "amount of reachable transactions")
+	assert.Equal(t, distances[hashD], 0, "distance of the tip")
+	assert.Equal(t, distances[hashC], 1, "distance of C")
+	assert.Equal(t, distances[hashB], 2, "distance of B")
+
+	_, reachable := distances[hashA]
+	assert.Equal(t, reachable, false, "A should be out of reach")
+}
+
+func TestPrune(t *testing.T) {
+	// A <- B <- C <- D <- E (E is not solid yet)
+	transactions := storeChain(t, "99999999999999999999999999G", 5, true)
+	setSolid(t, transactions[4].GetHash(), false)
+
+	// the transactions that are more than one step away from the tip are pruned
+	if _, err := Prune(0, 1); err != nil {
+		t.Fatal(err)
+	}
+	for i, stored := range []bool{false, false, false, true, true} {
+		contains, err := ContainsTransaction(transactions[i].GetHash())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, contains, stored, "transaction "+transactions[i].GetHash()+" stored")
+	}
+
+	// the pruned transaction that is still referenced becomes a solid entry point
+	assert.Equal(t, IsSolidEntryPoint(transactions[1].GetHash()), false)
+	assert.Equal(t, IsSolidEntryPoint(transactions[2].GetHash()), true)
+	assert.Equal(t, mustGetApproverHashes(t, transactions[3].GetHash()), []trinary.Trytes{transactions[4].GetHash()})
+
+	// unsolid transactions are never pruned - the solid entry point moves to the transaction that they reference
+	time.Sleep(time.Millisecond)
+	if _, err := Prune(time.Nanosecond, 0); err != nil {
+		t.Fatal(err)
+	}
+	contains, err := ContainsTransaction(transactions[3].GetHash())
+	assert.Equal(t, err, nil)
+	assert.Equal(t, contains, false)
+	contains, err = ContainsTransaction(transactions[4].GetHash())
+	assert.Equal(t, err, nil)
+	assert.Equal(t, contains, true)
+
+	assert.Equal(t, IsSolidEntryPoint(transactions[2].GetHash()), false)
+	assert.Equal(t, IsSolidEntryPoint(transactions[3].GetHash()), true)
+}
+
+// stores a chain of transactions (every transaction references its predecessor with its branch) together with their
+// metadata and approvers
+func storeChain(t *testing.T, nonce trinary.Trytes, length int, solid bool) []*value_transaction.ValueTransaction {
+	result := make([]*value_transaction.ValueTransaction, length)
+	for i := range result {
+		transaction := value_transaction.New()
+		if i == 0 {
+			transaction.SetNonce(nonce)
+		} else {
+			transaction.SetBranchTransactionHash(result[i-1].GetHash())
+		}
+
+		StoreTransaction(transaction)
+		StoreApprovers(approvers.New(transaction.GetHash()))
+		StoreTransactionMetadata(transactionmetadata.New(transaction.GetHash()))
+		setSolid(t, transaction.GetHash(), solid)
+
+		if i != 0 {
+			addApprover(t, result[i-1].GetHash(), transaction.GetHash())
+		}
+
+		result[i] = transaction
+	}
+
+	return result
+}
+
+func setSolid(t *testing.T, transactionHash trinary.Trytes, solid bool) {
+	cachedMetadata, err := GetTransactionMetadata(transactionHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cachedMetadata.Unwrap().SetSolid(solid)
+	cachedMetadata.Release()
+}
+
+func mustGetApproverHashes(t *testing.T, transactionHash trinary.Trytes) []trinary.Trytes {
+	cachedApprovers, err := GetApprovers(transactionHash)
+	if err != nil {
+		t.Fatal(err)
+	} else if cachedApprovers == nil {
+		return nil
+	}
+	defer cachedApprovers.Release()
+
+	return cachedApprovers.Unwrap().GetHashes()
+}
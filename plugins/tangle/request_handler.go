@@ -0,0 +1,23 @@
+package tangle
+
+import (
+	"github.com/iotaledger/goshimmer/plugins/gossip"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/iota.go/trinary"
+)
+
+// region plugin module setup //////////////////////////////////////////////////////////////////////////////////////////
+
+func configureRequestHandler(plugin *node.Plugin) {
+	gossip.Events.ReceiveTransactionRequest.Attach(events.NewClosure(func(neighbor *gossip.Neighbor, transactionHash trinary.Trytes) {
+		if cachedTransaction, err := GetTransaction(transactionHash); err != nil {
+			log.Errorf("Unable to answer request for transaction %s: %s", transactionHash, err.Error())
+		} else if cachedTransaction != nil {
+			neighbor.SendTransaction(cachedTransaction.Unwrap().MetaTransaction)
+			cachedTransaction.Release()
+		}
+	}))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
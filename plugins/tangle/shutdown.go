@@ -0,0 +1,106 @@
+package tangle
+
+import (
+	"sync"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/objectstorage"
+	"github.com/iotaledger/goshimmer/packages/workerpool"
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/node"
+)
+
+// region plugin module setup //////////////////////////////////////////////////////////////////////////////////////////
+
+func configureShutdown(plugin *node.Plugin) {
+	RegisterProcessingWorkerPool(workerPool)
+}
+
+func runShutdown(plugin *node.Plugin) {
+	daemon.BackgroundWorker("Tangle Shutdown", func() {
+		<-daemon.ShutdownSignal
+
+		log.Info("Waiting for processing to finish ...")
+		stopProcessingWorkerPools()
+		stopDatabaseWorkers()
+		addressIndexRebuild.Wait()
+		if err := drainSolidityPropagation(); err != nil {
+			log.Errorf("Unable to propagate solidity: %s", err.Error())
+		}
+		log.Info("Waiting for processing to finish ... done")
+
+		// wait for a running pruning and prevent further ones
+		pruningMutex.Lock()
+		defer pruningMutex.Unlock()
+
+		log.Info("Flushing caches to database ...")
+		if err := FlushCaches(); err != nil {
+			log.Errorf("Unable to flush caches: %s", err.Error())
+		}
+		objectstorage.WaitForPendingWrites()
+		log.Info("Flushing caches to database ... done")
+
+		// the database is closed last - every component that writes to it was stopped above
+		log.Info("Closing database ...")
+		if err := database.Close(); err != nil {
+			log.Errorf("Unable to close database: %s", err.Error())
+		}
+		log.Info("Closing database ... done")
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region public api ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Registers a worker pool that modifies the tangle. The pool is stopped and drained before the caches are flushed and
+// the database is closed on shutdown.
+func RegisterProcessingWorkerPool(pool *workerpool.WorkerPool) {
+	processingWorkerPoolsMutex.Lock()
+	processingWorkerPools = append(processingWorkerPools, pool)
+	processingWorkerPoolsMutex.Unlock()
+}
+
+// Registers a function that stops a component which writes to the database (and blocks until it stopped). It is called
+// together with the processing worker pools before the caches are flushed and the database is closed on shutdown.
+func RegisterDatabaseWorker(stop func()) {
+	databaseWorkersMutex.Lock()
+	databaseWorkers = append(databaseWorkers, stop)
+	databaseWorkersMutex.Unlock()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utility methods //////////////////////////////////////////////////////////////////////////////////////////////
+
+func stopProcessingWorkerPools() {
+	processingWorkerPoolsMutex.Lock()
+	defer processingWorkerPoolsMutex.Unlock()
+
+	for _, pool := range processingWorkerPools {
+		pool.StopAndWait()
+	}
+}
+
+func stopDatabaseWorkers() {
+	databaseWorkersMutex.Lock()
+	defer databaseWorkersMutex.Unlock()
+
+	for _, stop := range databaseWorkers {
+		stop()
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region constants and variables //////////////////////////////////////////////////////////////////////////////////////
+
+var processingWorkerPools = make([]*workerpool.WorkerPool, 0)
+
+var processingWorkerPoolsMutex sync.Mutex
+
+var databaseWorkers = make([]func(), 0)
+
+var databaseWorkersMutex sync.Mutex
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
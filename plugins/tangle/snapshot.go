@@ -0,0 +1,115 @@
+package tangle
+
+import (
+	"sync"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/goshimmer/packages/model/meta_transaction"
+	"github.com/iotaledger/goshimmer/packages/typeutils"
+	"github.com/iotaledger/iota.go/trinary"
+)
+
+// region public api ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Returns true if the given transaction is considered solid without being stored (genesis or pruned snapshot border).
+func IsSolidEntryPoint(transactionHash trinary.Trytes) (result bool) {
+	if transactionHash == meta_transaction.BRANCH_NULL_HASH {
+		return true
+	}
+
+	solidEntryPointsInit.Do(loadSolidEntryPoints)
+
+	solidEntryPointsMutex.RLock()
+	_, result = solidEntryPoints[transactionHash]
+	solidEntryPointsMutex.RUnlock()
+
+	return
+}
+
+func GetSolidEntryPoints() (result []trinary.Trytes) {
+	solidEntryPointsInit.Do(loadSolidEntryPoints)
+
+	solidEntryPointsMutex.RLock()
+	result = make([]trinary.Trytes, 0, len(solidEntryPoints))
+	for transactionHash := range solidEntryPoints {
+		result = append(result, transactionHash)
+	}
+	solidEntryPointsMutex.RUnlock()
+
+	return
+}
+
+// Replaces the current set of solid entry points and persists it in the database. It can be called before the plugin
+// is configured (i.e. to import a snapshot).
+func SetSolidEntryPoints(transactionHashes []trinary.Trytes) errors.IdentifiableError {
+	solidEntryPointsInit.Do(loadSolidEntryPoints)
+
+	newSolidEntryPoints := make(map[trinary.Trytes]bool, len(transactionHashes))
+	for _, transactionHash := range transactionHashes {
+		newSolidEntryPoints[transactionHash] = true
+	}
+
+	solidEntryPointsMutex.Lock()
+	defer solidEntryPointsMutex.Unlock()
+
+	for transactionHash := range solidEntryPoints {
+		if _, exists := newSolidEntryPoints[transactionHash]; !exists {
+			if err := solidEntryPointsDatabase.Delete(typeutils.StringToBytes(transactionHash)); err != nil {
+				return ErrDatabaseError.Derive(err, "failed to remove solid entry point")
+			}
+		}
+	}
+
+	for transactionHash := range newSolidEntryPoints {
+		if _, exists := solidEntryPoints[transactionHash]; !exists {
+			if err := solidEntryPointsDatabase.Set(typeutils.StringToBytes(transactionHash), []byte{}); err != nil {
+				return ErrDatabaseError.Derive(err, "failed to store solid entry point")
+			}
+		}
+	}
+
+	solidEntryPoints = newSolidEntryPoints
+
+	return nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region database /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var solidEntryPointsDatabase database.Database
+
+var solidEntryPointsInit sync.Once
+
+// opens the database lazily so the solid entry points can be accessed before the plugin is configured
+func loadSolidEntryPoints() {
+	if db, err := database.Get("solidEntryPoints"); err != nil {
+		panic(err)
+	} else {
+		solidEntryPointsDatabase = db
+	}
+
+	loadedSolidEntryPoints := make(map[trinary.Trytes]bool)
+	if _, err := solidEntryPointsDatabase.Iterate(func(key []byte, value []byte) bool {
+		loadedSolidEntryPoints[trinary.Trytes(string(key))] = true
+
+		return true
+	}, database.KeysOnly()); err != nil {
+		panic(err)
+	}
+
+	solidEntryPointsMutex.Lock()
+	solidEntryPoints = loadedSolidEntryPoints
+	solidEntryPointsMutex.Unlock()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region constants and variables //////////////////////////////////////////////////////////////////////////////////////
+
+var solidEntryPoints = make(map[trinary.Trytes]bool)
+
+var solidEntryPointsMutex sync.RWMutex
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
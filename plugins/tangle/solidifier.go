@@ -2,12 +2,14 @@ package tangle
 
 import (
 	"runtime"
+	"time"
 
 	"github.com/iotaledger/goshimmer/packages/errors"
 	"github.com/iotaledger/goshimmer/packages/model/approvers"
 	"github.com/iotaledger/goshimmer/packages/model/meta_transaction"
 	"github.com/iotaledger/goshimmer/packages/model/transactionmetadata"
 	"github.com/iotaledger/goshimmer/packages/model/value_transaction"
+	"github.com/iotaledger/goshimmer/packages/objectstorage"
 	"github.com/iotaledger/goshimmer/packages/workerpool"
 	"github.com/iotaledger/goshimmer/plugins/gossip"
 	"github.com/iotaledger/hive.go/daemon"
@@ -49,58 +51,49 @@ func runSolidifier(plugin *node.Plugin) {
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// Checks and updates the solid flag of a single transaction.
-func checkSolidity(transaction *value_transaction.ValueTransaction) (result bool, err errors.IdentifiableError) {
+// Checks and updates the solid flag of a single transaction. The second result is true if the transaction became solid
+// by this call.
+func checkSolidity(transaction *value_transaction.ValueTransaction) (result bool, solidified bool, err errors.IdentifiableError) {
 	// abort if transaction is solid already
-	txMetadata, metaDataErr := GetTransactionMetadata(transaction.GetHash(), transactionmetadata.New)
+	cachedMetadata, metaDataErr := GetTransactionMetadata(transaction.GetHash(), transactionmetadata.New)
 	if metaDataErr != nil {
 		err = metaDataErr
 
 		return
-	} else if txMetadata.GetSolid() {
+	}
+	defer cachedMetadata.Release()
+
+	txMetadata := cachedMetadata.Unwrap()
+	if txMetadata.GetSolid() {
 		result = true
 
 		return
 	}
 
-	// check solidity of branch transaction if it is not genesis
-	if branchTransactionHash := transaction.GetBranchTransactionHash(); branchTransactionHash != meta_transaction.BRANCH_NULL_HASH {
-		// abort if branch transaction is missing
-		if branchTransaction, branchErr := GetTransaction(branchTransactionHash); branchErr != nil {
-			err = branchErr
-
-			return
-		} else if branchTransaction == nil {
-			return
-		} else if branchTransactionMetadata, branchErr := GetTransactionMetadata(branchTransaction.GetHash(), transactionmetadata.New); branchErr != nil {
-			err = branchErr
+	// check solidity of branch and trunk transaction (both are checked to request all missing transactions at once)
+	branchSolid, branchErr := isApproveeSolid(transaction.GetBranchTransactionHash())
+	if branchErr != nil {
+		err = branchErr
 
-			return
-		} else if !branchTransactionMetadata.GetSolid() {
-			return
-		}
+		return
 	}
+	trunkSolid, trunkErr := isApproveeSolid(transaction.GetTrunkTransactionHash())
+	if trunkErr != nil {
+		err = trunkErr
 
-	// check solidity of trunk transaction if it is not genesis
-	if trunkTransactionHash := transaction.GetTrunkTransactionHash(); trunkTransactionHash != meta_transaction.BRANCH_NULL_HASH {
-		if trunkTransaction, trunkErr := GetTransaction(trunkTransactionHash); trunkErr != nil {
-			err = trunkErr
-
-			return
-		} else if trunkTransaction == nil {
-			return
-		} else if trunkTransactionMetadata, trunkErr := GetTransactionMetadata(trunkTransaction.GetHash(), transactionmetadata.New); trunkErr != nil {
-			err = trunkErr
-
-			return
-		} else if !trunkTransactionMetadata.GetSolid() {
-			return
-		}
+		return
+	}
+	if !branchSolid || !trunkSolid {
+		return
 	}
 
 	// mark transaction as solid and trigger event
 	if txMetadata.SetSolid(true) {
+		txMetadata.SetSolidificationTime(time.Now())
+
 		Events.TransactionSolid.Trigger(transaction)
+
+		solidified = true
 	}
 
 	result = true
@@ -108,79 +101,107 @@ func checkSolidity(transaction *value_transaction.ValueTransaction) (result bool
 	return
 }
 
-// Checks and updates the solid flag of a transaction and its approvers (future cone).
-func IsSolid(transaction *value_transaction.ValueTransaction) (bool, errors.IdentifiableError) {
-	if isSolid, err := checkSolidity(transaction); err != nil {
-		return false, err
-	} else if isSolid {
-		if err := propagateSolidity(transaction.GetHash()); err != nil {
-			return false, err
-		}
+// Checks if a referenced transaction is solid and requests it from the neighbors if it is missing.
+func isApproveeSolid(approveeHash trinary.Trytes) (bool, errors.IdentifiableError) {
+	// the genesis and the snapshot border are always solid
+	if IsSolidEntryPoint(approveeHash) {
+		return true, nil
 	}
 
-	return false, nil
-}
+	if containsApprovee, err := ContainsTransaction(approveeHash); err != nil {
+		return false, err
+	} else if !containsApprovee {
+		gossip.RequestTransaction(approveeHash)
 
-func propagateSolidity(transactionHash trinary.Trytes) errors.IdentifiableError {
-	if transactionApprovers, err := GetApprovers(transactionHash, approvers.New); err != nil {
-		return err
+		return false, nil
+	} else if cachedApproveeMetadata, err := GetTransactionMetadata(approveeHash, transactionmetadata.New); err != nil {
+		return false, err
 	} else {
-		for _, approverHash := range transactionApprovers.GetHashes() {
-			if approver, err := GetTransaction(approverHash); err != nil {
-				return err
-			} else if approver != nil {
-				if isSolid, err := checkSolidity(approver); err != nil {
-					return err
-				} else if isSolid {
-					if err := propagateSolidity(approver.GetHash()); err != nil {
-						return err
-					}
-				}
-			}
+		defer cachedApproveeMetadata.Release()
+
+		return cachedApproveeMetadata.Unwrap().GetSolid(), nil
+	}
+}
+
+// Checks and updates the solid flag of a transaction. If it became solid, the solidity of its approvers (future cone)
+// is updated asynchronously.
+func IsSolid(transaction *value_transaction.ValueTransaction) (bool, errors.IdentifiableError) {
+	isSolid, solidified, err := checkSolidity(transaction)
+	if err != nil {
+		return false, err
+	}
+
+	if solidified {
+		if err := queueSolidityPropagation(transaction.GetHash()); err != nil {
+			return isSolid, err
 		}
 	}
 
-	return nil
+	return isSolid, nil
 }
 
 func processMetaTransaction(plugin *node.Plugin, metaTransaction *meta_transaction.MetaTransaction) {
 	var newTransaction bool
-	if tx, err := GetTransaction(metaTransaction.GetHash(), func(transactionHash trinary.Trytes) *value_transaction.ValueTransaction {
+	if cachedTransaction, err := GetTransaction(metaTransaction.GetHash(), func(transactionHash trinary.Trytes) *value_transaction.ValueTransaction {
 		newTransaction = true
 
-		return value_transaction.FromMetaTransaction(metaTransaction)
+		tx := value_transaction.FromMetaTransaction(metaTransaction)
+		tx.SetModified(true)
+
+		return tx
 	}); err != nil {
 		log.Errorf("Unable to load transaction %s: %s", metaTransaction.GetHash(), err.Error())
-	} else if newTransaction {
-		processTransaction(plugin, tx)
+	} else {
+		defer cachedTransaction.Release()
+
+		if newTransaction {
+			processTransaction(plugin, cachedTransaction)
+		}
 	}
 }
 
-func processTransaction(plugin *node.Plugin, transaction *value_transaction.ValueTransaction) {
+func processTransaction(plugin *node.Plugin, cachedTransaction *CachedTransaction) {
+	transaction := cachedTransaction.Unwrap()
+
 	Events.TransactionStored.Trigger(transaction)
 
 	transactionHash := transaction.GetHash()
 
+	// create the metadata right away, so it is written together with the transaction
+	cachedMetadata, err := GetTransactionMetadata(transactionHash, transactionmetadata.New)
+	if err != nil {
+		log.Errorf("Unable to get metadata of transaction %s: %s", transactionHash, err.Error())
+		return
+	}
+	defer cachedMetadata.Release()
+
 	// register tx as approver for trunk
-	if trunkApprovers, err := GetApprovers(transaction.GetTrunkTransactionHash(), approvers.New); err != nil {
+	trunkApprovers, err := GetApprovers(transaction.GetTrunkTransactionHash(), approvers.New)
+	if err != nil {
 		log.Errorf("Unable to get approvers of transaction %s: %s", transaction.GetTrunkTransactionHash(), err.Error())
 		return
-	} else {
-		trunkApprovers.Add(transactionHash)
 	}
+	defer trunkApprovers.Release()
+	trunkApprovers.Unwrap().Add(transactionHash)
 
 	// register tx as approver for branch
-	if branchApprovers, err := GetApprovers(transaction.GetBranchTransactionHash(), approvers.New); err != nil {
+	branchApprovers, err := GetApprovers(transaction.GetBranchTransactionHash(), approvers.New)
+	if err != nil {
 		log.Errorf("Unable to get approvers of transaction %s: %s", transaction.GetBranchTransactionHash(), err.Error())
 		return
-	} else {
-		branchApprovers.Add(transactionHash)
 	}
+	defer branchApprovers.Release()
+	branchApprovers.Unwrap().Add(transactionHash)
 
 	// update the solidity flags of this transaction and its approvers
 	if _, err := IsSolid(transaction); err != nil {
 		log.Errorf("Unable to check solidity: %s", err.Error())
-		return
+	}
+
+	// persist the transaction together with its metadata and the approvers that reference it (a crash must not leave a
+	// stored transaction that is missing from the approvers of its trunk or branch)
+	if err := objectstorage.Write(cachedTransaction.CachedObject, cachedMetadata.CachedObject, trunkApprovers.CachedObject, branchApprovers.CachedObject); err != nil {
+		log.Errorf("Unable to store transaction %s: %s", transactionHash, err.Error())
 	}
 }
 
@@ -5,16 +5,21 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/model/transactionmetadata"
 	"github.com/iotaledger/goshimmer/packages/model/value_transaction"
+	"github.com/iotaledger/goshimmer/packages/objectstorage"
 	"github.com/iotaledger/goshimmer/plugins/gossip"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/node"
 	"github.com/iotaledger/hive.go/parameter"
 	"github.com/iotaledger/iota.go/trinary"
+	"github.com/magiconair/properties/assert"
 )
 
 func TestMain(m *testing.M) {
 	parameter.FetchConfig(false)
+	parameter.NodeConfig.Set(database.CFG_BACKEND, database.BACKEND_MEMORY)
 	os.Exit(m.Run())
 }
 
@@ -37,9 +42,11 @@ func TestSolidifier(t *testing.T) {
 
 	// setup event handlers
 	var wg sync.WaitGroup
-	Events.TransactionSolid.Attach(events.NewClosure(func(transaction *value_transaction.ValueTransaction) {
+	solidClosure := events.NewClosure(func(transaction *value_transaction.ValueTransaction) {
 		wg.Done()
-	}))
+	})
+	Events.TransactionSolid.Attach(solidClosure)
+	defer Events.TransactionSolid.Detach(solidClosure)
 
 	// issue transactions
 	wg.Add(4)
@@ -54,3 +61,51 @@ func TestSolidifier(t *testing.T) {
 	// shutdown test node
 	node.Shutdown()
 }
+
+func TestMissingApproveeIsRequested(t *testing.T) {
+	missingTransaction := value_transaction.New()
+	missingTransaction.SetNonce(trinary.Trytes("99999999999999999999999999D"))
+
+	transaction := value_transaction.New()
+	transaction.SetBranchTransactionHash(missingTransaction.GetHash())
+	StoreTransaction(transaction)
+	StoreTransactionMetadata(transactionmetadata.New(transaction.GetHash()))
+
+	isSolid, err := IsSolid(transaction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, isSolid, false)
+	assert.Equal(t, gossip.IsTransactionRequested(missingTransaction.GetHash()), true)
+}
+
+func TestTransactionIsStoredWithApprovers(t *testing.T) {
+	missingTransaction := value_transaction.New()
+	missingTransaction.SetNonce(trinary.Trytes("99999999999999999999999999E"))
+
+	transaction := value_transaction.New()
+	transaction.SetTrunkTransactionHash(missingTransaction.GetHash())
+	transaction.SetBranchTransactionHash(missingTransaction.GetHash())
+
+	processMetaTransaction(nil, transaction.MetaTransaction)
+
+	// the transaction, its metadata and the approvers of its trunk are written without waiting for a flush
+	assert.Equal(t, isPersisted(t, transactionStorage, transaction.GetHash()), true)
+	assert.Equal(t, isPersisted(t, transactionMetadataStorage, transaction.GetHash()), true)
+	assert.Equal(t, isPersisted(t, approversStorage, transaction.GetTrunkTransactionHash()), true)
+}
+
+func isPersisted(t *testing.T, storage *objectstorage.ObjectStorage, key trinary.Trytes) bool {
+	db, err := storage.GetDatabase()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contains, dbErr := db.Contains([]byte(key))
+	if dbErr != nil {
+		t.Fatal(dbErr)
+	}
+
+	return contains
+}
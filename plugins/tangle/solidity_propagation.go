@@ -0,0 +1,244 @@
+package tangle
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/parameter"
+	"github.com/iotaledger/iota.go/trinary"
+)
+
+// region plugin module setup //////////////////////////////////////////////////////////////////////////////////////////
+
+func configureSolidityPropagation(plugin *node.Plugin) {
+	propagationBatchSize = parameter.NodeConfig.GetInt(CFG_SOLIDITY_PROPAGATION_BATCH_SIZE)
+	if propagationBatchSize <= 0 {
+		propagationBatchSize = 1
+	}
+
+	propagationQueueSize = parameter.NodeConfig.GetInt(CFG_SOLIDITY_PROPAGATION_QUEUE_SIZE)
+}
+
+func runSolidityPropagation(plugin *node.Plugin) {
+	log.Info("Starting Solidity Propagation ...")
+
+	daemon.BackgroundWorker("Tangle Solidity Propagation", func() {
+		log.Info("Starting Solidity Propagation ... done")
+
+		failedSteps := 0
+		for {
+			select {
+			case <-daemon.ShutdownSignal:
+				log.Info("Stopping Solidity Propagation ... done")
+
+				return
+
+			case <-propagationSignal:
+				if err := processSolidityPropagationStep(); err != nil {
+					failedSteps++
+
+					log.Errorf("Unable to propagate solidity (attempt %d): %s", failedSteps, err.Error())
+
+					// the failed step was queued again, so we wait before retrying it
+					select {
+					case <-daemon.ShutdownSignal:
+						log.Info("Stopping Solidity Propagation ... done")
+
+						return
+
+					case <-time.After(getPropagationRetryTimeout(failedSteps)):
+					}
+				} else {
+					failedSteps = 0
+				}
+			}
+		}
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region public api ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+func GetSolidityPropagationQueueSize() (result int) {
+	propagationQueueMutex.Lock()
+	result = len(propagationQueue)
+	propagationQueueMutex.Unlock()
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utility methods //////////////////////////////////////////////////////////////////////////////////////////////
+
+// Schedules the solidity check of the future cone of a transaction that just became solid. If the queue is full, the
+// call blocks until the propagation made room for it, which slows down the solidifier until the propagation caught up.
+func queueSolidityPropagation(transactionHash trinary.Trytes) errors.IdentifiableError {
+	propagationQueueMutex.Lock()
+	// the remaining queue is processed on shutdown, so we stop waiting for the propagation when the node shuts down
+	for len(propagationQueue) >= propagationQueueSize && !isClosed(daemon.ShutdownSignal) {
+		queueSpace := propagationQueueSpace
+		propagationQueueMutex.Unlock()
+
+		select {
+		case <-queueSpace:
+		case <-daemon.ShutdownSignal:
+		}
+
+		propagationQueueMutex.Lock()
+	}
+	propagationQueue = append(propagationQueue, transactionHash)
+	propagationQueueMutex.Unlock()
+
+	signalSolidityPropagation()
+
+	return nil
+}
+
+// puts transactions back into the queue (regardless of its size), so their approvers are checked in a later step
+func requeueSolidityPropagation(transactionHashes []trinary.Trytes) {
+	if len(transactionHashes) == 0 {
+		return
+	}
+
+	propagationQueueMutex.Lock()
+	propagationQueue = append(propagationQueue, transactionHashes...)
+	propagationQueueMutex.Unlock()
+
+	signalSolidityPropagation()
+}
+
+func signalSolidityPropagation() {
+	select {
+	case propagationSignal <- struct{}{}:
+	default:
+	}
+}
+
+// Checks the approvers of at most propagationBatchSize queued transactions and queues the approvers that became solid
+// (the frontier of the propagation). If the step fails, the unprocessed transactions are queued again.
+func processSolidityPropagationStep() errors.IdentifiableError {
+	propagationProcessingMutex.Lock()
+	defer propagationProcessingMutex.Unlock()
+
+	propagationQueueMutex.Lock()
+	batchSize := propagationBatchSize
+	if len(propagationQueue) < batchSize {
+		batchSize = len(propagationQueue)
+	}
+	batch := append([]trinary.Trytes{}, propagationQueue[:batchSize]...)
+	propagationQueue = propagationQueue[batchSize:]
+	propagationQueueMutex.Unlock()
+
+	frontier := make([]trinary.Trytes, 0)
+	for i, transactionHash := range batch {
+		solidifiedApprovers, err := checkApproverSolidity(transactionHash)
+		frontier = append(frontier, solidifiedApprovers...)
+
+		if err != nil {
+			requeueSolidityPropagation(append(frontier, batch[i:]...))
+			signalQueueSpace()
+
+			return err
+		}
+	}
+
+	requeueSolidityPropagation(frontier)
+	signalQueueSpace()
+
+	if GetSolidityPropagationQueueSize() != 0 {
+		signalSolidityPropagation()
+	}
+
+	return nil
+}
+
+// wakes up the callers that wait for room in the queue
+func signalQueueSpace() {
+	propagationQueueMutex.Lock()
+	close(propagationQueueSpace)
+	propagationQueueSpace = make(chan struct{})
+	propagationQueueMutex.Unlock()
+}
+
+// processes the remaining queue (called on shutdown after the solidifier has stopped)
+func drainSolidityPropagation() errors.IdentifiableError {
+	for GetSolidityPropagationQueueSize() != 0 {
+		if err := processSolidityPropagationStep(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// returns the time to wait before a failed step is retried (the timeout doubles with every failed attempt but is capped
+// at PROPAGATION_MAX_RETRY_TIMEOUT)
+func getPropagationRetryTimeout(failedSteps int) time.Duration {
+	timeout := PROPAGATION_BASE_RETRY_TIMEOUT
+	for i := 1; i < failedSteps && timeout < PROPAGATION_MAX_RETRY_TIMEOUT; i++ {
+		timeout *= 2
+	}
+
+	if timeout > PROPAGATION_MAX_RETRY_TIMEOUT {
+		return PROPAGATION_MAX_RETRY_TIMEOUT
+	}
+
+	return timeout
+}
+
+// checks the direct approvers of a solid transaction and returns the ones that became solid (including the ones that
+// became solid before an error occurred)
+func checkApproverSolidity(transactionHash trinary.Trytes) (solidifiedApprovers []trinary.Trytes, err errors.IdentifiableError) {
+	cachedApprovers, err := GetApprovers(transactionHash)
+	if err != nil || cachedApprovers == nil {
+		return
+	}
+	approverHashes := cachedApprovers.Unwrap().GetHashes()
+	cachedApprovers.Release()
+
+	for _, approverHash := range approverHashes {
+		if approver, loadErr := loadTransaction(approverHash); loadErr != nil {
+			return solidifiedApprovers, loadErr
+		} else if approver != nil {
+			if _, solidified, solidityErr := checkSolidity(approver); solidityErr != nil {
+				return solidifiedApprovers, solidityErr
+			} else if solidified {
+				solidifiedApprovers = append(solidifiedApprovers, approverHash)
+			}
+		}
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region constants and variables //////////////////////////////////////////////////////////////////////////////////////
+
+var propagationBatchSize int
+
+var propagationQueueSize int
+
+var propagationQueue = make([]trinary.Trytes, 0)
+
+var propagationQueueMutex sync.Mutex
+
+// makes sure that only one batch is processed at a time
+var propagationProcessingMutex sync.Mutex
+
+var propagationSignal = make(chan struct{}, 1)
+
+// is closed (and replaced) whenever a step of the propagation removed transactions from the queue
+var propagationQueueSpace = make(chan struct{})
+
+const (
+	PROPAGATION_BASE_RETRY_TIMEOUT = 1 * time.Second
+	PROPAGATION_MAX_RETRY_TIMEOUT  = 1 * time.Minute
+)
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,138 @@
+package tangle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/goshimmer/packages/model/transactionmetadata"
+	"github.com/iotaledger/goshimmer/packages/model/value_transaction"
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/iota.go/trinary"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestSolidityPropagation(t *testing.T) {
+	previousBatchSize, previousQueueSize := propagationBatchSize, propagationQueueSize
+	defer func() {
+		propagationBatchSize, propagationQueueSize = previousBatchSize, previousQueueSize
+	}()
+	propagationBatchSize = 1
+	propagationQueueSize = 1
+
+	// the future cone of the first transaction is queued and solidified by the propagation (one transaction per step)
+	transactions := storeChain(t, "99999999999999999999999999H", 4, false)
+	solidificationStart := time.Now()
+
+	isSolid, err := IsSolid(transactions[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, isSolid, true)
+	assert.Equal(t, GetSolidityPropagationQueueSize(), 1)
+	assert.Equal(t, isSolidTransaction(t, transactions[1].GetHash()), false)
+
+	if err := processSolidityPropagationStep(); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, GetSolidityPropagationQueueSize(), 1)
+	assert.Equal(t, isSolidTransaction(t, transactions[1].GetHash()), true)
+	assert.Equal(t, isSolidTransaction(t, transactions[2].GetHash()), false)
+
+	if err := drainSolidityPropagation(); err != nil {
+		t.Fatal(err)
+	}
+	for _, transaction := range transactions {
+		assert.Equal(t, isSolidTransaction(t, transaction.GetHash()), true)
+		assert.Equal(t, getSolidificationTime(t, transaction.GetHash()).Before(solidificationStart), false)
+	}
+
+	// if the queue is full, the solidifier waits until the propagation made room for the transaction
+	previousShutdownSignal := daemon.ShutdownSignal
+	daemon.ShutdownSignal = make(chan int, 1)
+	defer func() {
+		daemon.ShutdownSignal = previousShutdownSignal
+	}()
+
+	queuedTransactions := storeChain(t, "99999999999999999999999999I", 1, false)
+	if _, err := IsSolid(queuedTransactions[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	transactions = storeChain(t, "99999999999999999999999999L", 2, false)
+	solidified := make(chan errors.IdentifiableError, 1)
+	go func() {
+		_, err := IsSolid(transactions[0])
+		solidified <- err
+	}()
+
+	select {
+	case <-solidified:
+		t.Fatal("the solidifier did not wait for the full queue")
+	case <-time.After(100 * time.Millisecond):
+	}
+	assert.Equal(t, GetSolidityPropagationQueueSize(), 1)
+
+	if err := processSolidityPropagationStep(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-solidified:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the solidifier did not continue after the propagation made room")
+	}
+	assert.Equal(t, GetSolidityPropagationQueueSize(), 1)
+	assert.Equal(t, isSolidTransaction(t, transactions[1].GetHash()), false)
+
+	if err := drainSolidityPropagation(); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, isSolidTransaction(t, transactions[1].GetHash()), true)
+
+	// unsolid transactions stop the propagation
+	transactions = storeChain(t, "99999999999999999999999999J", 1, false)
+	missingTransaction := value_transaction.New()
+	missingTransaction.SetNonce(trinary.Trytes("99999999999999999999999999K"))
+
+	unsolidTransaction := value_transaction.New()
+	unsolidTransaction.SetTrunkTransactionHash(missingTransaction.GetHash())
+	unsolidTransaction.SetBranchTransactionHash(transactions[0].GetHash())
+	StoreTransaction(unsolidTransaction)
+	StoreTransactionMetadata(transactionmetadata.New(unsolidTransaction.GetHash()))
+	addApprover(t, transactions[0].GetHash(), unsolidTransaction.GetHash())
+
+	if _, err := IsSolid(transactions[0]); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, isSolidTransaction(t, transactions[0].GetHash()), true)
+	assert.Equal(t, isSolidTransaction(t, unsolidTransaction.GetHash()), false)
+}
+
+func TestGetPropagationRetryTimeout(t *testing.T) {
+	assert.Equal(t, getPropagationRetryTimeout(1), PROPAGATION_BASE_RETRY_TIMEOUT)
+	assert.Equal(t, getPropagationRetryTimeout(2), 2*PROPAGATION_BASE_RETRY_TIMEOUT)
+	assert.Equal(t, getPropagationRetryTimeout(100), PROPAGATION_MAX_RETRY_TIMEOUT)
+}
+
+func isSolidTransaction(t *testing.T, transactionHash trinary.Trytes) bool {
+	cachedMetadata, err := GetTransactionMetadata(transactionHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cachedMetadata.Release()
+
+	return cachedMetadata.Unwrap().GetSolid()
+}
+
+func getSolidificationTime(t *testing.T, transactionHash trinary.Trytes) time.Time {
+	cachedMetadata, err := GetTransactionMetadata(transactionHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cachedMetadata.Release()
+
+	return cachedMetadata.Unwrap().GetSolidificationTime()
+}
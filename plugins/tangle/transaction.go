@@ -1,120 +1,85 @@
 package tangle
 
 import (
-	"github.com/iotaledger/goshimmer/packages/database"
-	"github.com/iotaledger/goshimmer/packages/datastructure"
 	"github.com/iotaledger/goshimmer/packages/errors"
 	"github.com/iotaledger/goshimmer/packages/model/value_transaction"
-	"github.com/iotaledger/goshimmer/packages/typeutils"
-	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/goshimmer/packages/objectstorage"
 	"github.com/iotaledger/iota.go/trinary"
 )
 
 // region public api ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-func GetTransaction(transactionHash trinary.Trytes, computeIfAbsent ...func(trinary.Trytes) *value_transaction.ValueTransaction) (result *value_transaction.ValueTransaction, err errors.IdentifiableError) {
-	if cacheResult := transactionCache.ComputeIfAbsent(transactionHash, func() interface{} {
-		if transaction, dbErr := getTransactionFromDatabase(transactionHash); dbErr != nil {
-			err = dbErr
-
-			return nil
-		} else if transaction != nil {
-			return transaction
-		} else {
-			if len(computeIfAbsent) >= 1 {
-				return computeIfAbsent[0](transactionHash)
-			}
-
-			return nil
+// Retrieves the transaction from the cache or the database. The returned reference has to be released after it was used.
+func GetTransaction(transactionHash trinary.Trytes, computeIfAbsent ...func(trinary.Trytes) *value_transaction.ValueTransaction) (*CachedTransaction, errors.IdentifiableError) {
+	var computeTransaction func(string) objectstorage.StorableObject
+	if len(computeIfAbsent) >= 1 {
+		computeTransaction = func(key string) objectstorage.StorableObject {
+			return computeIfAbsent[0](key)
 		}
-	}); !typeutils.IsInterfaceNil(cacheResult) {
-		result = cacheResult.(*value_transaction.ValueTransaction)
 	}
 
-	return
-}
-
-func ContainsTransaction(transactionHash trinary.Trytes) (result bool, err errors.IdentifiableError) {
-	if transactionCache.Contains(transactionHash) {
-		result = true
+	if cachedTransaction, err := transactionStorage.Load(transactionHash, computeTransaction); err != nil || cachedTransaction == nil {
+		return nil, err
 	} else {
-		result, err = databaseContainsTransaction(transactionHash)
+		return &CachedTransaction{cachedTransaction}, nil
 	}
+}
 
-	return
+func ContainsTransaction(transactionHash trinary.Trytes) (bool, errors.IdentifiableError) {
+	return transactionStorage.Contains(transactionHash)
 }
 
 func StoreTransaction(transaction *value_transaction.ValueTransaction) {
-	transactionCache.Set(transaction.GetHash(), transaction)
+	transactionStorage.Store(transaction.GetHash(), transaction).Release()
 }
 
-// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
-
-// region lru cache ////////////////////////////////////////////////////////////////////////////////////////////////////
+// Iterates over all stored transactions. Pending changes of the caches are written first, so the consumer also sees
+// the transactions that have not been persisted yet.
+func ForEachTransaction(consumer func(transaction *value_transaction.ValueTransaction)) errors.IdentifiableError {
+	pruningMutex.Lock()
+	defer pruningMutex.Unlock()
 
-var transactionCache = datastructure.NewLRUCache(TRANSACTION_CACHE_SIZE, &datastructure.LRUCacheOptions{
-	EvictionCallback: onEvictTransaction,
-})
+	return transactionStorage.ForEach(func(key []byte, object objectstorage.StorableObject) bool {
+		consumer(object.(*value_transaction.ValueTransaction))
 
-func onEvictTransaction(_ interface{}, value interface{}) {
-	if evictedTransaction := value.(*value_transaction.ValueTransaction); evictedTransaction.GetModified() {
-		go func(evictedTransaction *value_transaction.ValueTransaction) {
-			if err := storeTransactionInDatabase(evictedTransaction); err != nil {
-				panic(err)
-			}
-		}(evictedTransaction)
-	}
+		return true
+	})
 }
 
-const (
-	TRANSACTION_CACHE_SIZE = 50000
-)
+// removes the transaction from the cache and the database without writing pending changes
+func deleteTransaction(transactionHash trinary.Trytes) errors.IdentifiableError {
+	return transactionStorage.Delete(transactionHash)
+}
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// region database /////////////////////////////////////////////////////////////////////////////////////////////////////
-
-var transactionDatabase database.Database
+// region cached transaction ///////////////////////////////////////////////////////////////////////////////////////////
 
-func configureTransactionDatabase(plugin *node.Plugin) {
-	if db, err := database.Get("transaction"); err != nil {
-		panic(err)
-	} else {
-		transactionDatabase = db
-	}
+// CachedTransaction is a reference to a transaction of the cache that keeps it in memory until it is released.
+type CachedTransaction struct {
+	*objectstorage.CachedObject
 }
 
-func storeTransactionInDatabase(transaction *value_transaction.ValueTransaction) errors.IdentifiableError {
-	if transaction.GetModified() {
-		if err := transactionDatabase.Set(typeutils.StringToBytes(transaction.GetHash()), transaction.MetaTransaction.GetBytes()); err != nil {
-			return ErrDatabaseError.Derive(err, "failed to store transaction")
-		}
+func (cachedTransaction *CachedTransaction) Unwrap() *value_transaction.ValueTransaction {
+	return cachedTransaction.Get().(*value_transaction.ValueTransaction)
+}
 
-		transaction.SetModified(false)
-	}
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
-	return nil
-}
+// region object storage //////////////////////////////////////////////////////////////////////////////////////////////
 
-func getTransactionFromDatabase(transactionHash trinary.Trytes) (*value_transaction.ValueTransaction, errors.IdentifiableError) {
-	txData, err := transactionDatabase.Get(typeutils.StringToBytes(transactionHash))
-	if err != nil {
-		if err == database.ErrKeyNotFound {
-			return nil, nil
-		} else {
-			return nil, ErrDatabaseError.Derive(err, "failed to retrieve transaction")
-		}
-	}
+var transactionStorage = objectstorage.New("transaction", marshalTransaction, unmarshalTransaction, objectstorage.CacheSize(TRANSACTION_CACHE_SIZE))
 
-	return value_transaction.FromBytes(txData), nil
+func marshalTransaction(object objectstorage.StorableObject) ([]byte, error) {
+	return object.(*value_transaction.ValueTransaction).MetaTransaction.GetBytes(), nil
 }
 
-func databaseContainsTransaction(transactionHash trinary.Trytes) (bool, errors.IdentifiableError) {
-	if contains, err := transactionDatabase.Contains(typeutils.StringToBytes(transactionHash)); err != nil {
-		return contains, ErrDatabaseError.Derive(err, "failed to check if the transaction exists")
-	} else {
-		return contains, nil
-	}
+func unmarshalTransaction(key []byte, data []byte) (objectstorage.StorableObject, error) {
+	return value_transaction.FromBytes(data), nil
 }
 
+const (
+	TRANSACTION_CACHE_SIZE = 50000
+)
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
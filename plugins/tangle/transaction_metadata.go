@@ -1,129 +1,82 @@
 package tangle
 
 import (
-	"github.com/iotaledger/goshimmer/packages/database"
-	"github.com/iotaledger/goshimmer/packages/datastructure"
 	"github.com/iotaledger/goshimmer/packages/errors"
 	"github.com/iotaledger/goshimmer/packages/model/transactionmetadata"
-	"github.com/iotaledger/goshimmer/packages/typeutils"
-	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/goshimmer/packages/objectstorage"
 	"github.com/iotaledger/iota.go/trinary"
 )
 
 // region public api ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-func GetTransactionMetadata(transactionHash trinary.Trytes, computeIfAbsent ...func(trinary.Trytes) *transactionmetadata.TransactionMetadata) (result *transactionmetadata.TransactionMetadata, err errors.IdentifiableError) {
-	if cacheResult := transactionMetadataCache.ComputeIfAbsent(transactionHash, func() interface{} {
-		if transactionMetadata, dbErr := getTransactionMetadataFromDatabase(transactionHash); dbErr != nil {
-			err = dbErr
-
-			return nil
-		} else if transactionMetadata != nil {
-			return transactionMetadata
-		} else {
-			if len(computeIfAbsent) >= 1 {
-				return computeIfAbsent[0](transactionHash)
-			}
-
-			return nil
+// Retrieves the metadata of the transaction from the cache or the database. The returned reference has to be released
+// after it was used (and after all changes were made to the metadata).
+func GetTransactionMetadata(transactionHash trinary.Trytes, computeIfAbsent ...func(trinary.Trytes) *transactionmetadata.TransactionMetadata) (*CachedTransactionMetadata, errors.IdentifiableError) {
+	var computeTransactionMetadata func(string) objectstorage.StorableObject
+	if len(computeIfAbsent) >= 1 {
+		computeTransactionMetadata = func(key string) objectstorage.StorableObject {
+			return computeIfAbsent[0](key)
 		}
-	}); !typeutils.IsInterfaceNil(cacheResult) {
-		result = cacheResult.(*transactionmetadata.TransactionMetadata)
 	}
 
-	return
-}
-
-func ContainsTransactionMetadata(transactionHash trinary.Trytes) (result bool, err errors.IdentifiableError) {
-	if transactionMetadataCache.Contains(transactionHash) {
-		result = true
+	if cachedMetadata, err := transactionMetadataStorage.Load(transactionHash, computeTransactionMetadata); err != nil || cachedMetadata == nil {
+		return nil, err
 	} else {
-		result, err = databaseContainsTransactionMetadata(transactionHash)
+		return &CachedTransactionMetadata{cachedMetadata}, nil
 	}
+}
 
-	return
+func ContainsTransactionMetadata(transactionHash trinary.Trytes) (bool, errors.IdentifiableError) {
+	return transactionMetadataStorage.Contains(transactionHash)
 }
 
 func StoreTransactionMetadata(transactionMetadata *transactionmetadata.TransactionMetadata) {
-	transactionMetadataCache.Set(transactionMetadata.GetHash(), transactionMetadata)
+	transactionMetadataStorage.Store(transactionMetadata.GetHash(), transactionMetadata).Release()
 }
 
-// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+// removes the transaction metadata from the cache and the database without writing pending changes
+func deleteTransactionMetadata(transactionHash trinary.Trytes) errors.IdentifiableError {
+	return transactionMetadataStorage.Delete(transactionHash)
+}
 
-// region lru cache ////////////////////////////////////////////////////////////////////////////////////////////////////
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
-var transactionMetadataCache = datastructure.NewLRUCache(TRANSACTION_METADATA_CACHE_SIZE, &datastructure.LRUCacheOptions{
-	EvictionCallback: onEvictTransactionMetadata,
-})
+// region cached transaction metadata //////////////////////////////////////////////////////////////////////////////////
 
-func onEvictTransactionMetadata(_ interface{}, value interface{}) {
-	if evictedTransactionMetadata := value.(*transactionmetadata.TransactionMetadata); evictedTransactionMetadata.GetModified() {
-		go func(evictedTransactionMetadata *transactionmetadata.TransactionMetadata) {
-			if err := storeTransactionMetadataInDatabase(evictedTransactionMetadata); err != nil {
-				panic(err)
-			}
-		}(evictedTransactionMetadata)
-	}
+// CachedTransactionMetadata is a reference to the metadata of a transaction that keeps it in memory until it is released.
+type CachedTransactionMetadata struct {
+	*objectstorage.CachedObject
 }
 
-const (
-	TRANSACTION_METADATA_CACHE_SIZE = 50000
-)
+func (cachedMetadata *CachedTransactionMetadata) Unwrap() *transactionmetadata.TransactionMetadata {
+	return cachedMetadata.Get().(*transactionmetadata.TransactionMetadata)
+}
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// region database /////////////////////////////////////////////////////////////////////////////////////////////////////
+// region object storage //////////////////////////////////////////////////////////////////////////////////////////////
 
-var transactionMetadataDatabase database.Database
+var transactionMetadataStorage = objectstorage.New("transactionMetadata", marshalTransactionMetadata, unmarshalTransactionMetadata, objectstorage.CacheSize(TRANSACTION_METADATA_CACHE_SIZE))
 
-func configureTransactionMetaDataDatabase(plugin *node.Plugin) {
-	if db, err := database.Get("transactionMetadata"); err != nil {
-		panic(err)
+func marshalTransactionMetadata(object objectstorage.StorableObject) ([]byte, error) {
+	if marshaledMetadata, err := object.(*transactionmetadata.TransactionMetadata).Marshal(); err != nil {
+		return nil, err
 	} else {
-		transactionMetadataDatabase = db
-	}
-}
-
-func storeTransactionMetadataInDatabase(metadata *transactionmetadata.TransactionMetadata) errors.IdentifiableError {
-	if metadata.GetModified() {
-		if marshaledMetadata, err := metadata.Marshal(); err != nil {
-			return err
-		} else {
-			if err := transactionMetadataDatabase.Set(typeutils.StringToBytes(metadata.GetHash()), marshaledMetadata); err != nil {
-				return ErrDatabaseError.Derive(err, "failed to store transaction metadata")
-			}
-
-			metadata.SetModified(false)
-		}
+		return marshaledMetadata, nil
 	}
-
-	return nil
 }
 
-func getTransactionMetadataFromDatabase(transactionHash trinary.Trytes) (*transactionmetadata.TransactionMetadata, errors.IdentifiableError) {
-	txMetadata, err := transactionMetadataDatabase.Get(typeutils.StringToBytes(transactionHash))
-	if err != nil {
-		if err == database.ErrKeyNotFound {
-			return nil, nil
-		} else {
-			return nil, ErrDatabaseError.Derive(err, "failed to retrieve transaction")
-		}
-	}
-
+func unmarshalTransactionMetadata(key []byte, data []byte) (objectstorage.StorableObject, error) {
 	var result transactionmetadata.TransactionMetadata
-	if err := result.Unmarshal(txMetadata); err != nil {
-		panic(err)
+	if err := result.Unmarshal(data); err != nil {
+		return nil, err
 	}
 
 	return &result, nil
 }
 
-func databaseContainsTransactionMetadata(transactionHash trinary.Trytes) (bool, errors.IdentifiableError) {
-	if contains, err := transactionMetadataDatabase.Contains(typeutils.StringToBytes(transactionHash)); err != nil {
-		return contains, ErrDatabaseError.Derive(err, "failed to check if the transaction metadata exists")
-	} else {
-		return contains, nil
-	}
-}
+const (
+	TRANSACTION_METADATA_CACHE_SIZE = 50000
+)
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,272 @@
+package tangle
+
+import (
+	"github.com/iotaledger/goshimmer/packages/errors"
+	"github.com/iotaledger/goshimmer/packages/model/value_transaction"
+	"github.com/iotaledger/iota.go/trinary"
+)
+
+// region public api ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Creates a walker that iterates over the transactions that are referenced by the given transaction (trunk and branch).
+// The given transaction itself is not part of the walk.
+func NewPastConeWalker(transactionHash trinary.Trytes, options ...WalkerOption) *Walker {
+	return newWalker(transactionHash, getApprovees, options...)
+}
+
+// Creates a walker that iterates over the transactions that reference the given transaction (its approvers). The given
+// transaction itself is not part of the walk.
+func NewFutureConeWalker(transactionHash trinary.Trytes, options ...WalkerOption) *Walker {
+	return newWalker(transactionHash, getApproverHashes, options...)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region walker ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Walker iterates over a cone of the tangle in breadth first (default) or depth first order. Every transaction is
+// returned at most once. Transactions that are not stored (i.e. solid entry points or missing transactions) are skipped.
+//
+//	walker := tangle.NewPastConeWalker(transactionHash, tangle.StopAtSolid())
+//	for walker.Next() {
+//		transaction := walker.Transaction()
+//		...
+//	}
+//	if err := walker.Err(); err != nil {
+//		...
+//	}
+type Walker struct {
+	options         *WalkerOptions
+	getNextHashes   func(*value_transaction.ValueTransaction) ([]trinary.Trytes, errors.IdentifiableError)
+	startHash       trinary.Trytes
+	started         bool
+	pendingHashes   []trinary.Trytes
+	current         *value_transaction.ValueTransaction
+	skipCurrentCone bool
+	err             errors.IdentifiableError
+}
+
+func newWalker(transactionHash trinary.Trytes, getNextHashes func(*value_transaction.ValueTransaction) ([]trinary.Trytes, errors.IdentifiableError), optionalOptions ...WalkerOption) *Walker {
+	options := DEFAULT_WALKER_OPTIONS.Override(optionalOptions...)
+	if options.VisitedSet == nil {
+		options.VisitedSet = make(map[trinary.Trytes]bool)
+	}
+
+	return &Walker{
+		options:       options,
+		getNextHashes: getNextHashes,
+		startHash:     transactionHash,
+		pendingHashes: make([]trinary.Trytes, 0),
+	}
+}
+
+// Moves to the next transaction of the cone and returns false if the walk is finished or failed.
+func (walker *Walker) Next() bool {
+	if walker.err != nil {
+		return false
+	}
+
+	if !walker.started {
+		walker.started = true
+		walker.options.VisitedSet[walker.startHash] = true
+
+		if startTransaction, err := loadTransaction(walker.startHash); err != nil {
+			walker.err = err
+
+			return false
+		} else if startTransaction != nil {
+			walker.queueNextHashes(startTransaction)
+		}
+	} else if walker.current != nil && !walker.skipCurrentCone {
+		walker.queueNextHashes(walker.current)
+	}
+
+	walker.current = nil
+	walker.skipCurrentCone = false
+
+	for walker.err == nil && len(walker.pendingHashes) != 0 {
+		transactionHash := walker.popPendingHash()
+		if walker.options.VisitedSet[transactionHash] {
+			continue
+		}
+		walker.options.VisitedSet[transactionHash] = true
+
+		if IsSolidEntryPoint(transactionHash) {
+			continue
+		}
+
+		transaction, err := loadTransaction(transactionHash)
+		if err != nil {
+			walker.err = err
+		} else if transaction != nil {
+			if stop, err := walker.isBoundary(transaction); err != nil {
+				walker.err = err
+			} else if !stop {
+				walker.current = transaction
+
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Returns the transaction that the walker currently points to.
+func (walker *Walker) Transaction() *value_transaction.ValueTransaction {
+	return walker.current
+}
+
+// Prevents the walker from walking further into the cone of the current transaction.
+func (walker *Walker) Skip() {
+	walker.skipCurrentCone = true
+}
+
+// Returns the error that aborted the walk.
+func (walker *Walker) Err() errors.IdentifiableError {
+	return walker.err
+}
+
+func (walker *Walker) queueNextHashes(transaction *value_transaction.ValueTransaction) {
+	if nextHashes, err := walker.getNextHashes(transaction); err != nil {
+		walker.err = err
+	} else {
+		walker.pendingHashes = append(walker.pendingHashes, nextHashes...)
+	}
+}
+
+func (walker *Walker) popPendingHash() (result trinary.Trytes) {
+	lastIndex := len(walker.pendingHashes) - 1
+
+	if walker.options.DepthFirst {
+		result = walker.pendingHashes[lastIndex]
+		walker.pendingHashes = walker.pendingHashes[:lastIndex]
+	} else {
+		result = walker.pendingHashes[0]
+		walker.pendingHashes = walker.pendingHashes[1:]
+	}
+
+	return
+}
+
+// checks if the walk has to stop at the given transaction (it is neither returned nor walked through)
+func (walker *Walker) isBoundary(transaction *value_transaction.ValueTransaction) (bool, errors.IdentifiableError) {
+	if walker.options.StopCondition != nil && walker.options.StopCondition(transaction) {
+		return true, nil
+	}
+
+	if walker.options.StopAtSolid || walker.options.StopAtUnsolid {
+		cachedMetadata, err := GetTransactionMetadata(transaction.GetHash())
+		if err != nil {
+			return false, err
+		}
+
+		solid := false
+		if cachedMetadata != nil {
+			solid = cachedMetadata.Unwrap().GetSolid()
+			cachedMetadata.Release()
+		}
+		if (solid && walker.options.StopAtSolid) || (!solid && walker.options.StopAtUnsolid) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region walker options ///////////////////////////////////////////////////////////////////////////////////////////////
+
+var DEFAULT_WALKER_OPTIONS = &WalkerOptions{
+	DepthFirst: false,
+}
+
+// Walks the cone depth first instead of breadth first.
+func DepthFirst() WalkerOption {
+	return func(args *WalkerOptions) {
+		args.DepthFirst = true
+	}
+}
+
+// Stops the walk at solid transactions (i.e. to find the missing part of the past cone of an unsolid transaction).
+func StopAtSolid() WalkerOption {
+	return func(args *WalkerOptions) {
+		args.StopAtSolid = true
+	}
+}
+
+// Stops the walk at unsolid transactions.
+func StopAtUnsolid() WalkerOption {
+	return func(args *WalkerOptions) {
+		args.StopAtUnsolid = true
+	}
+}
+
+// Stops the walk at the transactions that satisfy the given condition.
+func StopCondition(condition func(transaction *value_transaction.ValueTransaction) bool) WalkerOption {
+	return func(args *WalkerOptions) {
+		args.StopCondition = condition
+	}
+}
+
+// Uses the given set to keep track of the visited transactions, so it can be shared by several walks. Transactions that
+// are already contained in the set are not visited again.
+func VisitedSet(visitedSet map[trinary.Trytes]bool) WalkerOption {
+	return func(args *WalkerOptions) {
+		args.VisitedSet = visitedSet
+	}
+}
+
+type WalkerOptions struct {
+	DepthFirst    bool
+	StopAtSolid   bool
+	StopAtUnsolid bool
+	StopCondition func(transaction *value_transaction.ValueTransaction) bool
+	VisitedSet    map[trinary.Trytes]bool
+}
+
+func (options WalkerOptions) Override(optionalOptions ...WalkerOption) *WalkerOptions {
+	result := &options
+	for _, option := range optionalOptions {
+		option(result)
+	}
+
+	return result
+}
+
+type WalkerOption func(*WalkerOptions)
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utility methods //////////////////////////////////////////////////////////////////////////////////////////////
+
+func getApprovees(transaction *value_transaction.ValueTransaction) ([]trinary.Trytes, errors.IdentifiableError) {
+	return []trinary.Trytes{transaction.GetTrunkTransactionHash(), transaction.GetBranchTransactionHash()}, nil
+}
+
+func getApproverHashes(transaction *value_transaction.ValueTransaction) ([]trinary.Trytes, errors.IdentifiableError) {
+	if cachedApprovers, err := GetApprovers(transaction.GetHash()); err != nil {
+		return nil, err
+	} else if cachedApprovers == nil {
+		return nil, nil
+	} else {
+		defer cachedApprovers.Release()
+
+		return cachedApprovers.Unwrap().GetHashes(), nil
+	}
+}
+
+// loads a transaction without keeping a reference to it (stored transactions are never modified, so the walker can keep
+// using it after it was released)
+func loadTransaction(transactionHash trinary.Trytes) (*value_transaction.ValueTransaction, errors.IdentifiableError) {
+	if cachedTransaction, err := GetTransaction(transactionHash); err != nil || cachedTransaction == nil {
+		return nil, err
+	} else {
+		defer cachedTransaction.Release()
+
+		return cachedTransaction.Unwrap(), nil
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
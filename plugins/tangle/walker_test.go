@@ -0,0 +1,74 @@
+package tangle
+
+import (
+	"testing"
+
+	"github.com/iotaledger/goshimmer/packages/model/approvers"
+	"github.com/iotaledger/goshimmer/packages/model/transactionmetadata"
+	"github.com/iotaledger/goshimmer/packages/model/value_transaction"
+	"github.com/iotaledger/iota.go/trinary"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestWalker(t *testing.T) {
+	// create a small tangle: 1 <- 2 <- 3 and 2 <- 4 -> 3
+	transaction1 := value_transaction.New()
+	transaction1.SetNonce(trinary.Trytes("99999999999999999999999999B"))
+	transaction2 := value_transaction.New()
+	transaction2.SetBranchTransactionHash(transaction1.GetHash())
+	transaction3 := value_transaction.New()
+	transaction3.SetBranchTransactionHash(transaction2.GetHash())
+	transaction4 := value_transaction.New()
+	transaction4.SetBranchTransactionHash(transaction2.GetHash())
+	transaction4.SetTrunkTransactionHash(transaction3.GetHash())
+
+	for _, transaction := range []*value_transaction.ValueTransaction{transaction1, transaction2, transaction3, transaction4} {
+		StoreTransaction(transaction)
+		StoreApprovers(approvers.New(transaction.GetHash()))
+		StoreTransactionMetadata(transactionmetadata.New(transaction.GetHash()))
+	}
+	addApprover(t, transaction1.GetHash(), transaction2.GetHash())
+	addApprover(t, transaction2.GetHash(), transaction3.GetHash())
+	addApprover(t, transaction2.GetHash(), transaction4.GetHash())
+	addApprover(t, transaction3.GetHash(), transaction4.GetHash())
+
+	assert.Equal(t, walk(t, NewPastConeWalker(transaction4.GetHash())), []trinary.Trytes{transaction3.GetHash(), transaction2.GetHash(), transaction1.GetHash()})
+	assert.Equal(t, walk(t, NewPastConeWalker(transaction4.GetHash(), DepthFirst())), []trinary.Trytes{transaction2.GetHash(), transaction1.GetHash(), transaction3.GetHash()})
+	assert.Equal(t, len(walk(t, NewFutureConeWalker(transaction1.GetHash()))), 3)
+
+	// stop at boundaries
+	cachedMetadata, _ := GetTransactionMetadata(transaction2.GetHash())
+	cachedMetadata.Unwrap().SetSolid(true)
+	cachedMetadata.Release()
+	assert.Equal(t, walk(t, NewPastConeWalker(transaction4.GetHash(), StopAtSolid())), []trinary.Trytes{transaction3.GetHash()})
+	assert.Equal(t, walk(t, NewPastConeWalker(transaction4.GetHash(), StopCondition(func(transaction *value_transaction.ValueTransaction) bool {
+		return transaction.GetHash() == transaction3.GetHash()
+	}))), []trinary.Trytes{transaction2.GetHash(), transaction1.GetHash()})
+
+	// shared visited set
+	visitedSet := map[trinary.Trytes]bool{transaction2.GetHash(): true}
+	assert.Equal(t, walk(t, NewPastConeWalker(transaction4.GetHash(), VisitedSet(visitedSet))), []trinary.Trytes{transaction3.GetHash()})
+}
+
+func walk(t *testing.T, walker *Walker) (result []trinary.Trytes) {
+	result = make([]trinary.Trytes, 0)
+	for walker.Next() {
+		result = append(result, walker.Transaction().GetHash())
+	}
+
+	if err := walker.Err(); err != nil {
+		t.Error(err)
+	}
+
+	return
+}
+
+func addApprover(t *testing.T, transactionHash trinary.Trytes, approverHash trinary.Trytes) {
+	cachedApprovers, err := GetApprovers(transactionHash, approvers.New)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cachedApprovers.Unwrap().Add(approverHash)
+	cachedApprovers.Release()
+}
@@ -21,3 +21,8 @@ func GetRandomTip() (result trinary.Trytes) {
 func GetTipsCount() int {
 	return tips.Size()
 }
+
+// Adds a transaction to the tip set (i.e. the tips of an imported snapshot).
+func AddTip(transactionHash trinary.Trytes) {
+	tips.Set(transactionHash, transactionHash)
+}
@@ -0,0 +1,15 @@
+package webapi_attach_to_tangle
+
+import (
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	CFG_MAX_BUNDLE_SIZE         = "webapi.attachToTangle.maxBundleSize"
+	CFG_MAX_CONCURRENT_REQUESTS = "webapi.attachToTangle.maxConcurrentRequests"
+)
+
+func init() {
+	flag.Int(CFG_MAX_BUNDLE_SIZE, 20, "maximum amount of transactions that can be attached with a single request")
+	flag.Int(CFG_MAX_CONCURRENT_REQUESTS, 1, "maximum amount of requests that do the proof-of-work at the same time")
+}
@@ -0,0 +1,122 @@
+package webapi_attach_to_tangle
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/model/meta_transaction"
+	"github.com/iotaledger/goshimmer/packages/model/value_transaction"
+	"github.com/iotaledger/goshimmer/packages/pow"
+	"github.com/iotaledger/goshimmer/plugins/gossip"
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/parameter"
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/trinary"
+	"github.com/labstack/echo"
+)
+
+var PLUGIN = node.NewPlugin("WebAPI AttachToTangle Endpoint", node.Enabled, configure)
+
+func configure(plugin *node.Plugin) {
+	maxBundleSize = parameter.NodeConfig.GetInt(CFG_MAX_BUNDLE_SIZE)
+	powSemaphore = make(chan struct{}, parameter.NodeConfig.GetInt(CFG_MAX_CONCURRENT_REQUESTS))
+
+	webapi.AddEndpoint("attachToTangle", Handler)
+}
+
+// Connects the given transactions (ordered from head to tail) to the trunk and branch transaction and does the
+// proof-of-work for each of them. The request is aborted when the client disconnects.
+func Handler(c echo.Context) error {
+	start := time.Now()
+
+	var request webRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, webResponse{Error: err.Error()})
+	}
+
+	if !isValidHash(request.TrunkTransaction) || !isValidHash(request.BranchTransaction) {
+		return c.JSON(http.StatusBadRequest, webResponse{Error: "invalid trunk or branch transaction hash"})
+	}
+
+	// a higher weight magnitude than the one of the network only burns the cpu of the node
+	if maxWeightMagnitude := gossip.GetMinWeightMagnitude(); request.MinWeightMagnitude > maxWeightMagnitude {
+		return c.JSON(http.StatusBadRequest, webResponse{Error: "the weight magnitude must not exceed " + strconv.Itoa(maxWeightMagnitude)})
+	}
+
+	if len(request.Trytes) == 0 || len(request.Trytes) > maxBundleSize {
+		return c.JSON(http.StatusBadRequest, webResponse{Error: "the amount of transactions must be between 1 and " + strconv.Itoa(maxBundleSize)})
+	}
+
+	transactions := make([]*value_transaction.ValueTransaction, len(request.Trytes))
+	for i, transactionTrytes := range request.Trytes {
+		if len(transactionTrytes)*3 != meta_transaction.MARSHALED_TOTAL_SIZE {
+			return c.JSON(http.StatusBadRequest, webResponse{Error: "invalid transaction length"})
+		}
+
+		transactionTrits, err := trinary.TrytesToTrits(transactionTrytes)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, webResponse{Error: err.Error()})
+		}
+
+		transactions[i] = value_transaction.FromMetaTransaction(meta_transaction.FromTrits(transactionTrits))
+	}
+
+	select {
+	case powSemaphore <- struct{}{}:
+		defer func() { <-powSemaphore }()
+	default:
+		return c.JSON(http.StatusServiceUnavailable, webResponse{Error: "too many concurrent proof-of-work requests"})
+	}
+
+	// every transaction references its successor, so we start at the tail
+	for i := len(transactions) - 1; i >= 0; i-- {
+		transactions[i].SetBranchTransactionHash(request.BranchTransaction)
+		if i == len(transactions)-1 {
+			transactions[i].SetTrunkTransactionHash(request.TrunkTransaction)
+		} else {
+			transactions[i].SetTrunkTransactionHash(transactions[i+1].GetHash())
+		}
+
+		if err := pow.DoPoW(c.Request().Context(), transactions[i], request.MinWeightMagnitude); err != nil {
+			if pow.ErrInvalidWeightMagnitude.Equals(err) {
+				return c.JSON(http.StatusBadRequest, webResponse{Error: err.Error()})
+			}
+
+			return c.JSON(http.StatusInternalServerError, webResponse{Error: err.Error()})
+		}
+	}
+
+	result := make([]trinary.Trytes, len(transactions))
+	for i, transaction := range transactions {
+		result[i] = trinary.MustTritsToTrytes(transaction.GetTrits())
+	}
+
+	return c.JSON(http.StatusOK, webResponse{
+		Duration: time.Since(start).Nanoseconds() / 1e6,
+		Trytes:   result,
+	})
+}
+
+func isValidHash(hash trinary.Trytes) bool {
+	return len(hash) == consts.HashTrytesSize && trinary.ValidTrytes(hash) == nil
+}
+
+type webRequest struct {
+	TrunkTransaction   trinary.Trytes   `json:"trunkTransaction"`
+	BranchTransaction  trinary.Trytes   `json:"branchTransaction"`
+	MinWeightMagnitude int              `json:"minWeightMagnitude"`
+	Trytes             []trinary.Trytes `json:"trytes"`
+}
+
+type webResponse struct {
+	Duration int64            `json:"duration"`
+	Trytes   []trinary.Trytes `json:"trytes,omitempty"`
+	Error    string           `json:"error,omitempty"`
+}
+
+var maxBundleSize int
+
+// limits the amount of requests that do the proof-of-work at the same time
+var powSemaphore chan struct{}
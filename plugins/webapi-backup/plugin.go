@@ -0,0 +1,47 @@
+package webapi_backup
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/backup"
+	"github.com/iotaledger/goshimmer/plugins/tangle"
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/labstack/echo"
+)
+
+var PLUGIN = node.NewPlugin("WebAPI Backup Endpoint", node.Disabled, func(plugin *node.Plugin) {
+	webapi.AddEndpoint("backup", Handler)
+})
+var log = logger.NewLogger("WebAPI Backup Endpoint")
+
+// Streams a backup of the running node (see backup.Create) to the client. The key pair of the node is only part of the
+// backup if the query parameter includeIdentity is set to true.
+func Handler(c echo.Context) error {
+	includeIdentity := c.QueryParam("includeIdentity") == "true"
+
+	if err := tangle.FlushCaches(); err != nil {
+		return c.JSON(http.StatusInternalServerError, webResponse{Error: err.Error()})
+	}
+
+	response := c.Response()
+	response.Header().Set(echo.HeaderContentType, echo.MIMEOctetStream)
+	response.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"goshimmer-%s.backup\"", time.Now().Format("20060102-150405")))
+	response.WriteHeader(http.StatusOK)
+
+	// the status was sent already, so errors can only be reported by aborting the stream
+	if _, err := backup.Create(response, includeIdentity); err != nil {
+		log.Errorf("Unable to create backup: %s", err.Error())
+
+		return err
+	}
+
+	return nil
+}
+
+type webResponse struct {
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,41 @@
+package webapi_database_stats
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/iotaledger/goshimmer/plugins/metrics"
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/labstack/echo"
+)
+
+var PLUGIN = node.NewPlugin("WebAPI Database Stats Endpoint", node.Enabled, func(plugin *node.Plugin) {
+	webapi.AddEndpoint("getDatabaseStats", Handler)
+})
+
+func Handler(c echo.Context) error {
+	start := time.Now()
+
+	stats := metrics.GetDatabaseStats()
+	if stats == nil {
+		return c.JSON(http.StatusServiceUnavailable, webResponse{Error: "the database statistics were not measured yet"})
+	}
+
+	return c.JSON(http.StatusOK, webResponse{
+		Duration:               time.Since(start).Nanoseconds() / 1e6,
+		LSMSize:                stats.LSMSize,
+		ValueLogSize:           stats.ValueLogSize,
+		KeyCounts:              stats.KeyCounts,
+		RewrittenValueLogFiles: metrics.GetRewrittenValueLogFiles(),
+	})
+}
+
+type webResponse struct {
+	Duration               int64             `json:"duration"`
+	LSMSize                int64             `json:"lsmSize"`
+	ValueLogSize           int64             `json:"valueLogSize"`
+	KeyCounts              map[string]uint64 `json:"keyCounts,omitempty"`
+	RewrittenValueLogFiles uint64            `json:"rewrittenValueLogFiles"`
+	Error                  string            `json:"error,omitempty"`
+}
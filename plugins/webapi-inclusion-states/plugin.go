@@ -0,0 +1,64 @@
+package webapi_inclusion_states
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/iotaledger/goshimmer/plugins/tangle"
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/iota.go/trinary"
+	"github.com/labstack/echo"
+)
+
+var PLUGIN = node.NewPlugin("WebAPI Inclusion States Endpoint", node.Enabled, func(plugin *node.Plugin) {
+	webapi.AddEndpoint("getInclusionStates", Handler)
+})
+
+func Handler(c echo.Context) error {
+	start := time.Now()
+
+	var request webRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, webResponse{Error: err.Error()})
+	}
+
+	states := make([]inclusionState, len(request.Transactions))
+	for i, transactionHash := range request.Transactions {
+		state, err := tangle.GetInclusionState(transactionHash)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, webResponse{Error: err.Error()})
+		}
+
+		states[i] = inclusionState{
+			Transaction:    transactionHash,
+			Known:          state.Known,
+			Solid:          state.Solid,
+			Confirmed:      state.Confirmed,
+			ApprovalWeight: state.ApprovalWeight,
+		}
+	}
+
+	return c.JSON(http.StatusOK, webResponse{
+		Duration: time.Since(start).Nanoseconds() / 1e6,
+		States:   states,
+	})
+}
+
+type webRequest struct {
+	Transactions []trinary.Trytes `json:"transactions"`
+}
+
+type webResponse struct {
+	Duration int64            `json:"duration"`
+	States   []inclusionState `json:"states,omitempty"`
+	Error    string           `json:"error,omitempty"`
+}
+
+type inclusionState struct {
+	Transaction    trinary.Trytes `json:"transaction"`
+	Known          bool           `json:"known"`
+	Solid          bool           `json:"solid"`
+	Confirmed      bool           `json:"confirmed"`
+	ApprovalWeight uint64         `json:"approvalWeight"`
+}
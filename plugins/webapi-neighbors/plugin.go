@@ -0,0 +1,95 @@
+package webapi_neighbors
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/iotaledger/goshimmer/plugins/gossip"
+	"github.com/iotaledger/goshimmer/plugins/metrics"
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/labstack/echo"
+)
+
+var PLUGIN = node.NewPlugin("WebAPI Neighbors Endpoint", node.Enabled, func(plugin *node.Plugin) {
+	webapi.AddEndpoint("getNeighbors", Handler)
+	webapi.AddEndpoint("addNeighbor", AddNeighborHandler)
+	webapi.AddEndpoint("removeNeighbor", RemoveNeighborHandler)
+})
+
+func Handler(c echo.Context) error {
+	start := time.Now()
+
+	neighborMetrics := metrics.GetNeighborMetrics()
+
+	result := make([]neighbor, 0)
+	for identifier, gossipNeighbor := range gossip.GetNeighbors() {
+		statistics := gossipNeighbor.GetStatistics()
+
+		entry := neighbor{
+			Identity:              identifier,
+			Address:               gossipNeighbor.GetAddress().String(),
+			Host:                  gossipNeighbor.GetHost(),
+			Port:                  gossipNeighbor.GetPort(),
+			Static:                gossipNeighbor.IsStatic(),
+			Connected:             gossipNeighbor.GetInitiatedProtocol() != nil || gossipNeighbor.GetAcceptedProtocol() != nil,
+			RoundTripTime:         gossipNeighbor.GetRoundTripTime().Nanoseconds() / 1e6,
+			HeartbeatTimeouts:     neighborMetrics[identifier].HeartbeatTimeouts,
+			ReceivedTransactions:  statistics.ReceivedTransactions,
+			NewTransactions:       statistics.NewTransactions,
+			DuplicateTransactions: statistics.DuplicateTransactions,
+			InvalidTransactions:   statistics.InvalidTransactions,
+			SentTransactions:      statistics.SentTransactions,
+			DroppedTransactions:   statistics.DroppedTransactions,
+			DroppedRequests:       statistics.DroppedRequests,
+			BytesReceived:         statistics.BytesReceived,
+			BytesSent:             statistics.BytesSent,
+			ReceivedTPS:           neighborMetrics[identifier].ReceivedTPS,
+			SentTPS:               neighborMetrics[identifier].SentTPS,
+		}
+
+		if lastSeen := gossipNeighbor.GetLastSeen(); !lastSeen.IsZero() {
+			entry.LastSeen = lastSeen.Unix()
+		}
+
+		result = append(result, entry)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Identity < result[j].Identity
+	})
+
+	return c.JSON(http.StatusOK, webResponse{
+		Duration:  time.Since(start).Nanoseconds() / 1e6,
+		Neighbors: result,
+	})
+}
+
+type webResponse struct {
+	Duration  int64      `json:"duration"`
+	Neighbors []neighbor `json:"neighbors"`
+}
+
+type neighbor struct {
+	Identity              string `json:"identity"`
+	Address               string `json:"address"`
+	Host                  string `json:"host,omitempty"`
+	Port                  uint16 `json:"port"`
+	Static                bool   `json:"static"`
+	Connected             bool   `json:"connected"`
+	LastSeen              int64  `json:"lastSeen"`
+	RoundTripTime         int64  `json:"roundTripTime"`
+	HeartbeatTimeouts     uint64 `json:"heartbeatTimeouts"`
+	ReceivedTransactions  uint64 `json:"receivedTransactions"`
+	NewTransactions       uint64 `json:"newTransactions"`
+	DuplicateTransactions uint64 `json:"duplicateTransactions"`
+	InvalidTransactions   uint64 `json:"invalidTransactions"`
+	SentTransactions      uint64 `json:"sentTransactions"`
+	DroppedTransactions   uint64 `json:"droppedTransactions"`
+	DroppedRequests       uint64 `json:"droppedRequests"`
+	BytesReceived         uint64 `json:"bytesReceived"`
+	BytesSent             uint64 `json:"bytesSent"`
+	ReceivedTPS           uint64 `json:"receivedTps"`
+	SentTPS               uint64 `json:"sentTps"`
+}
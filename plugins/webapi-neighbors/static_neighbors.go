@@ -0,0 +1,66 @@
+package webapi_neighbors
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/iotaledger/goshimmer/plugins/gossip"
+	"github.com/labstack/echo"
+)
+
+// Adds a static neighbor (identity@host:port) that is reconnected until it gets removed again.
+func AddNeighborHandler(c echo.Context) error {
+	start := time.Now()
+
+	var request addNeighborRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, staticNeighborResponse{Error: err.Error()})
+	}
+
+	neighbor, err := gossip.ParseNeighbor(request.Neighbor)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, staticNeighborResponse{Error: err.Error()})
+	}
+
+	gossip.AddNeighbor(neighbor)
+
+	return c.JSON(http.StatusOK, staticNeighborResponse{
+		Duration: time.Since(start).Nanoseconds() / 1e6,
+		Identity: neighbor.GetIdentity().StringIdentifier,
+	})
+}
+
+// Removes the neighbor with the given identity (no matter if it is a static neighbor or one of the autopeering).
+func RemoveNeighborHandler(c echo.Context) error {
+	start := time.Now()
+
+	var request removeNeighborRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, staticNeighborResponse{Error: err.Error()})
+	}
+
+	if _, exists := gossip.GetNeighbor(request.Identity); !exists {
+		return c.JSON(http.StatusNotFound, staticNeighborResponse{Error: "unknown neighbor " + request.Identity})
+	}
+
+	gossip.RemoveNeighbor(request.Identity)
+
+	return c.JSON(http.StatusOK, staticNeighborResponse{
+		Duration: time.Since(start).Nanoseconds() / 1e6,
+		Identity: request.Identity,
+	})
+}
+
+type addNeighborRequest struct {
+	Neighbor string `json:"neighbor"`
+}
+
+type removeNeighborRequest struct {
+	Identity string `json:"identity"`
+}
+
+type staticNeighborResponse struct {
+	Duration int64  `json:"duration"`
+	Identity string `json:"identity,omitempty"`
+	Error    string `json:"error,omitempty"`
+}